@@ -1,7 +1,12 @@
 package stripe
 
 import (
+	"context"
+	"fmt"
 	"log"
+	"net/http"
+	"net/url"
+	"time"
 
 	"github.com/stripe/stripe-go/v72"
 	"github.com/stripe/stripe-go/v72/client"
@@ -9,18 +14,149 @@ import (
 
 // Config stores Stripe's API configuration
 type Config struct {
-	APIToken string
+	APIToken           string
+	APIVersion         string
+	APIBaseURL         string
+	StripeAccount      string
+	MaxNetworkRetries  int64
+	RateLimit          int
+	UseIdempotencyKeys bool
+	ExpectedLivemode   *bool
 }
 
-// Client returns a new Client for accessing Stripe.
-func (c *Config) Client() (*client.API, error) {
+// providerMeta is what resources and data sources get from the provider.
+type providerMeta struct {
+	*client.API
+	useIdempotencyKeys bool
+}
+
+// Client returns a new Client for accessing Stripe. The only request it sends
+// checks the mode of the API token when an expected mode is set, so that a
+// client for the wrong mode is never handed out.
+func (c *Config) Client(ctx context.Context) (*client.API, error) {
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+
 	stripe.SetAppInfo(&stripe.AppInfo{
 		Name: "terraform-provider-stripe",
 	})
 
 	client := &client.API{}
-	client.Init(c.APIToken, nil)
+	client.Init(c.APIToken, c.backends())
+
+	if err := c.checkLivemode(ctx, client); err != nil {
+		return nil, err
+	}
 	log.Printf("[INFO] Stripe Client configured.")
 
 	return client, nil
 }
+
+func (c *Config) validate() error {
+	if c.APIToken == "" {
+		return fmt.Errorf("the Stripe API token is missing, set api_token or STRIPE_API_TOKEN")
+	}
+
+	if c.APIBaseURL != "" {
+		u, err := url.Parse(c.APIBaseURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("api_base_url %q isn't an HTTP(S) URL", c.APIBaseURL)
+		}
+	}
+
+	if c.MaxNetworkRetries < 0 {
+		return fmt.Errorf("max_network_retries can't be negative, got %d", c.MaxNetworkRetries)
+	}
+
+	if c.RateLimit < 0 {
+		return fmt.Errorf("rate_limit can't be negative, got %d", c.RateLimit)
+	}
+
+	return nil
+}
+
+// checkLivemode makes sure the client's API token is from the expected mode,
+// if any.
+func (c *Config) checkLivemode(ctx context.Context, client *client.API) error {
+	if c.ExpectedLivemode == nil {
+		return nil
+	}
+
+	livemode, err := tokenLivemode(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	if livemode != *c.ExpectedLivemode {
+		return fmt.Errorf("the Stripe API token is a %s key but expected_livemode is %t", modeName(livemode), *c.ExpectedLivemode)
+	}
+
+	return nil
+}
+
+// backends builds the Stripe backends used by the client. Settings are kept
+// on them rather than on stripe-go's globals, so that aliased providers
+// don't interfere with each other.
+func (c *Config) backends() *stripe.Backends {
+	headers := http.Header{}
+	if c.APIVersion != "" {
+		headers.Set("Stripe-Version", c.APIVersion)
+	}
+	if c.StripeAccount != "" {
+		headers.Set("Stripe-Account", c.StripeAccount)
+	}
+
+	httpClient := &http.Client{
+		Timeout: 80 * time.Second,
+		Transport: &headerTransport{
+			headers: headers,
+			next: &rateLimitTransport{
+				maxRetries: c.MaxNetworkRetries,
+				interval:   rateLimitInterval(c.RateLimit),
+				next:       http.DefaultTransport,
+			},
+		},
+	}
+
+	// Backends complete their config, so each one gets its own.
+	config := func() *stripe.BackendConfig {
+		return &stripe.BackendConfig{
+			HTTPClient:        httpClient,
+			MaxNetworkRetries: stripe.Int64(c.MaxNetworkRetries),
+		}
+	}
+
+	apiConfig := config()
+	if c.APIBaseURL != "" {
+		apiConfig.URL = stripe.String(c.APIBaseURL)
+	}
+
+	return &stripe.Backends{
+		API:     stripe.GetBackendWithConfig(stripe.APIBackend, apiConfig),
+		Connect: stripe.GetBackendWithConfig(stripe.ConnectBackend, config()),
+		Uploads: stripe.GetBackendWithConfig(stripe.UploadsBackend, config()),
+	}
+}
+
+// tokenLivemode tells whether the client's API token is a live mode key.
+// Accounts don't expose their mode, but balances are scoped to the mode of
+// the key used to retrieve them.
+func tokenLivemode(ctx context.Context, client *client.API) (bool, error) {
+	params := &stripe.BalanceParams{}
+	params.Context = ctx
+
+	balance, err := client.Balance.Get(params)
+	if err != nil {
+		return false, fmt.Errorf("unable to check the mode of the Stripe API token: %s", err)
+	}
+
+	return balance.Livemode, nil
+}
+
+func modeName(livemode bool) string {
+	if livemode {
+		return "live mode"
+	}
+	return "test mode"
+}
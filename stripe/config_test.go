@@ -0,0 +1,147 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+func TestConfigValidate(t *testing.T) {
+	cases := []struct {
+		name   string
+		config Config
+		err    string
+	}{
+		{
+			name:   "valid",
+			config: Config{APIToken: "sk_test_123"},
+		},
+		{
+			name:   "valid base URL",
+			config: Config{APIToken: "sk_test_123", APIBaseURL: "http://localhost:12111"},
+		},
+		{
+			name:   "empty token",
+			config: Config{},
+			err:    "the Stripe API token is missing",
+		},
+		{
+			name:   "base URL without scheme",
+			config: Config{APIToken: "sk_test_123", APIBaseURL: "localhost:12111"},
+			err:    `api_base_url "localhost:12111" isn't an HTTP(S) URL`,
+		},
+		{
+			name:   "base URL without host",
+			config: Config{APIToken: "sk_test_123", APIBaseURL: "https://"},
+			err:    `api_base_url "https://" isn't an HTTP(S) URL`,
+		},
+		{
+			name:   "unparsable base URL",
+			config: Config{APIToken: "sk_test_123", APIBaseURL: "https://%zz"},
+			err:    `api_base_url "https://%zz" isn't an HTTP(S) URL`,
+		},
+		{
+			name:   "negative max network retries",
+			config: Config{APIToken: "sk_test_123", MaxNetworkRetries: -1},
+			err:    "max_network_retries can't be negative, got -1",
+		},
+		{
+			name:   "negative rate limit",
+			config: Config{APIToken: "sk_test_123", RateLimit: -1},
+			err:    "rate_limit can't be negative, got -1",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := c.config.validate()
+			if c.err == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), c.err) {
+				t.Errorf("expected error %q, got %v", c.err, err)
+			}
+		})
+	}
+}
+
+func TestConfigClientLivemode(t *testing.T) {
+	cases := []struct {
+		name     string
+		expected *bool
+		livemode bool
+		err      string
+	}{
+		{
+			name: "not checked",
+		},
+		{
+			name:     "matching test mode",
+			expected: stripe.Bool(false),
+		},
+		{
+			name:     "matching live mode",
+			expected: stripe.Bool(true),
+			livemode: true,
+		},
+		{
+			name:     "test key expected to be live",
+			expected: stripe.Bool(true),
+			err:      "the Stripe API token is a test mode key but expected_livemode is true",
+		},
+		{
+			name:     "live key expected to be test",
+			expected: stripe.Bool(false),
+			livemode: true,
+			err:      "the Stripe API token is a live mode key but expected_livemode is false",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if r.URL.Path != "/v1/balance" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+				}
+				fmt.Fprintf(w, `{"object": "balance", "livemode": %t}`, c.livemode)
+			}))
+			defer server.Close()
+
+			config := Config{
+				APIToken:         "sk_test_123",
+				APIBaseURL:       server.URL,
+				ExpectedLivemode: c.expected,
+			}
+
+			client, err := config.Client(context.Background())
+			if c.err == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+				if client == nil {
+					t.Error("expected a client")
+				}
+			} else if err == nil || !strings.Contains(err.Error(), c.err) {
+				t.Errorf("expected error %q, got %v", c.err, err)
+			}
+
+			want := 0
+			if c.expected != nil {
+				want = 1
+			}
+			if requests != want {
+				t.Errorf("expected %d requests, got %d", want, requests)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package stripe
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+func dataSourceStripeAccount() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceStripeAccountRead,
+
+		Schema: map[string]*schema.Schema{
+			// Defaults to the account of the API token.
+			"account_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"country": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"default_currency": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"email": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"business_profile": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"mcc": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"product_description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"support_email": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"support_phone": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"support_url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			// Status of each capability, e.g. active.
+			"capabilities": {
+				Type:     schema.TypeMap,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
+			"charges_enabled": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"payouts_enabled": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceStripeAccountRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	var account *stripe.Account
+	var err error
+	if accountID, ok := d.GetOk("account_id"); ok {
+		params := &stripe.AccountParams{}
+		params.Context = ctx
+		account, err = client.Account.GetByID(accountID.(string), params)
+	} else {
+		account, err = client.Account.Get()
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// stripe-go only knows a fixed list of capabilities, they're read from
+	// the raw response instead.
+	var raw struct {
+		Capabilities map[string]string `json:"capabilities"`
+	}
+	if err := json.Unmarshal(account.LastResponse.RawJSON, &raw); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(account.ID)
+	d.Set("account_id", account.ID)
+	d.Set("type", account.Type)
+	d.Set("country", account.Country)
+	d.Set("default_currency", account.DefaultCurrency)
+	d.Set("email", account.Email)
+	d.Set("business_profile", flattenAccountBusinessProfile(account.BusinessProfile))
+	d.Set("capabilities", raw.Capabilities)
+	d.Set("charges_enabled", account.ChargesEnabled)
+	d.Set("payouts_enabled", account.PayoutsEnabled)
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package stripe
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+func dataSourceStripeBalance() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceStripeBalanceRead,
+
+		Schema: map[string]*schema.Schema{
+			"available": balanceAmountsSchema(),
+			"pending":   balanceAmountsSchema(),
+			"livemode": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func balanceAmountsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"amount": {
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+				"currency": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"source_types": {
+					Type:     schema.TypeMap,
+					Elem:     &schema.Schema{Type: schema.TypeInt},
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+func flattenBalanceAmounts(in []*stripe.Amount) []map[string]interface{} {
+	out := make([]map[string]interface{}, 0, len(in))
+	for _, amount := range in {
+		sourceTypes := make(map[string]interface{}, len(amount.SourceTypes))
+		for sourceType, value := range amount.SourceTypes {
+			sourceTypes[string(sourceType)] = int(value)
+		}
+
+		out = append(out, map[string]interface{}{
+			"amount":       amount.Value,
+			"currency":     amount.Currency,
+			"source_types": sourceTypes,
+		})
+	}
+	return out
+}
+
+func dataSourceStripeBalanceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.BalanceParams{}
+	params.Context = ctx
+
+	balance, err := client.Balance.Get(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// There's a single balance per account and mode.
+	d.SetId("balance")
+	d.Set("available", flattenBalanceAmounts(balance.Available))
+	d.Set("pending", flattenBalanceAmounts(balance.Pending))
+	d.Set("livemode", balance.Livemode)
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package stripe
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+func dataSourceStripeConnection() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceStripeConnectionRead,
+
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"livemode": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"ok": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceStripeConnectionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.BalanceParams{}
+	params.Context = ctx
+
+	balance, err := client.Balance.Get(params)
+	if err != nil {
+		return diag.Errorf("unable to reach Stripe with the configured API token: %s", err)
+	}
+
+	account, err := client.Account.Get()
+	if err != nil {
+		return diag.Errorf("unable to retrieve the Stripe account of the configured API token: %s", err)
+	}
+
+	d.SetId(account.ID)
+	d.Set("account_id", account.ID)
+	d.Set("livemode", balance.Livemode)
+	d.Set("ok", true)
+
+	return nil
+}
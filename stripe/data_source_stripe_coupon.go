@@ -0,0 +1,94 @@
+package stripe
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+func dataSourceStripeCoupon() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceStripeCouponRead,
+
+		Schema: map[string]*schema.Schema{
+			"coupon_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"percent_off": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+			"amount_off": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"currency": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"duration": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"duration_in_months": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"max_redemptions": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"valid": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"times_redeemed": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"metadata": {
+				Type:     schema.TypeMap,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceStripeCouponRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+	couponID := d.Get("coupon_id").(string)
+
+	params := &stripe.CouponParams{}
+	params.Context = ctx
+
+	coupon, err := client.Coupons.Get(couponID, params)
+	if stripeErr, ok := err.(*stripe.Error); ok && stripeErr.HTTPStatusCode == http.StatusNotFound {
+		return diag.Errorf("no coupon found with id %q", couponID)
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(coupon.ID)
+	d.Set("name", coupon.Name)
+	d.Set("percent_off", coupon.PercentOff)
+	d.Set("amount_off", coupon.AmountOff)
+	d.Set("currency", coupon.Currency)
+	d.Set("duration", coupon.Duration)
+	d.Set("duration_in_months", coupon.DurationInMonths)
+	d.Set("max_redemptions", coupon.MaxRedemptions)
+	d.Set("valid", coupon.Valid)
+	d.Set("times_redeemed", coupon.TimesRedeemed)
+	d.Set("metadata", coupon.Metadata)
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package stripe
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+func dataSourceStripePrice() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceStripePriceRead,
+
+		Schema: map[string]*schema.Schema{
+			"price_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"price_id", "lookup_key"},
+			},
+			"lookup_key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"price_id", "lookup_key"},
+			},
+			"currency": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"unit_amount": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"recurring": {
+				Type:     schema.TypeMap,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
+			"product": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"tax_behavior": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"active": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceStripePriceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	var price *stripe.Price
+	if priceID, ok := d.GetOk("price_id"); ok {
+		params := &stripe.PriceParams{}
+		params.Context = ctx
+
+		var err error
+		price, err = client.Prices.Get(priceID.(string), params)
+		if stripeErr, ok := err.(*stripe.Error); ok && stripeErr.HTTPStatusCode == http.StatusNotFound {
+			return diag.Errorf("no price found with id %q", priceID)
+		}
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	} else {
+		lookupKey := d.Get("lookup_key").(string)
+
+		params := &stripe.PriceListParams{
+			LookupKeys: stripe.StringSlice([]string{lookupKey}),
+		}
+		params.Context = ctx
+
+		matches, err := listAll[*stripe.Price](ctx, client.Prices.List(params), 0, nil)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		switch len(matches) {
+		case 0:
+			return diag.Errorf("no price found with lookup key %q", lookupKey)
+		case 1:
+			price = matches[0]
+		default:
+			return diag.Errorf("%d prices found with lookup key %q, use price_id instead", len(matches), lookupKey)
+		}
+	}
+
+	d.SetId(price.ID)
+	d.Set("price_id", price.ID)
+	d.Set("lookup_key", price.LookupKey)
+	d.Set("currency", price.Currency)
+	d.Set("unit_amount", price.UnitAmount)
+	d.Set("recurring", flattenPriceRecurring(price.Recurring))
+	if price.Product != nil {
+		d.Set("product", price.Product.ID)
+	}
+	d.Set("tax_behavior", price.TaxBehavior)
+	d.Set("active", price.Active)
+
+	return nil
+}
@@ -0,0 +1,153 @@
+package stripe
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+func dataSourceStripePrices() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceStripePricesRead,
+
+		Schema: map[string]*schema.Schema{
+			"product": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"currency": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"active": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateEnum("one_time", "recurring"),
+			},
+			"lookup_keys": {
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Optional: true,
+			},
+			"limit": dataSourceListLimitSchema(),
+			"prices": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"product": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"active": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"currency": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"unit_amount": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"nickname": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"lookup_key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"recurring": {
+							Type:     schema.TypeMap,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Computed: true,
+						},
+						"tax_behavior": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceStripePricesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.PriceListParams{}
+	params.Context = ctx
+
+	if product, ok := d.GetOk("product"); ok {
+		params.Product = stripe.String(product.(string))
+	}
+
+	if currency, ok := d.GetOk("currency"); ok {
+		params.Currency = stripe.String(currency.(string))
+	}
+
+	if active, ok := d.GetOkExists("active"); ok {
+		params.Active = stripe.Bool(active.(bool))
+	}
+
+	if priceType, ok := d.GetOk("type"); ok {
+		params.Type = stripe.String(priceType.(string))
+	}
+
+	params.LookupKeys = expandStringList(d, "lookup_keys")
+
+	limit := d.Get("limit").(int)
+	setListLimit(&params.ListParams, limit)
+
+	list, err := listAll[*stripe.Price](ctx, client.Prices.List(params), limit, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ids := make([]string, 0, len(list))
+	prices := make([]map[string]interface{}, 0, len(list))
+	for _, price := range list {
+		product := ""
+		if price.Product != nil {
+			product = price.Product.ID
+		}
+
+		ids = append(ids, price.ID)
+		prices = append(prices, map[string]interface{}{
+			"id":           price.ID,
+			"product":      product,
+			"active":       price.Active,
+			"currency":     price.Currency,
+			"unit_amount":  price.UnitAmount,
+			"nickname":     price.Nickname,
+			"lookup_key":   price.LookupKey,
+			"type":         price.Type,
+			"recurring":    flattenPriceRecurring(price.Recurring),
+			"tax_behavior": price.TaxBehavior,
+		})
+	}
+
+	d.SetId(strconv.Itoa(schema.HashString(strings.Join(ids, ","))))
+	d.Set("prices", prices)
+
+	return nil
+}
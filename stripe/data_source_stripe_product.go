@@ -0,0 +1,104 @@
+package stripe
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+func dataSourceStripeProduct() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceStripeProductRead,
+
+		Schema: map[string]*schema.Schema{
+			"product_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"product_id", "name"},
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"product_id", "name"},
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"active": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"metadata": {
+				Type:     schema.TypeMap,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
+			"statement_descriptor": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"unit_label": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceStripeProductRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	var product *stripe.Product
+	if productID, ok := d.GetOk("product_id"); ok {
+		params := &stripe.ProductParams{}
+		params.Context = ctx
+
+		var err error
+		product, err = client.Products.Get(productID.(string), params)
+		if stripeErr, ok := err.(*stripe.Error); ok && stripeErr.HTTPStatusCode == http.StatusNotFound {
+			return diag.Errorf("no product found with id %q", productID)
+		}
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	} else {
+		name := d.Get("name").(string)
+
+		params := &stripe.ProductListParams{}
+		params.Context = ctx
+
+		// Stripe can't filter products by name, so they're filtered here.
+		matches, err := listAll(ctx, client.Products.List(params), 0, func(product *stripe.Product) bool {
+			return product.Name == name
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		switch len(matches) {
+		case 0:
+			return diag.Errorf("no product found with name %q", name)
+		case 1:
+			product = matches[0]
+		default:
+			return diag.Errorf("%d products found with name %q, use product_id instead", len(matches), name)
+		}
+	}
+
+	d.SetId(product.ID)
+	d.Set("product_id", product.ID)
+	d.Set("name", product.Name)
+	d.Set("type", product.Type)
+	d.Set("active", product.Active)
+	d.Set("metadata", product.Metadata)
+	d.Set("statement_descriptor", product.StatementDescriptor)
+	d.Set("unit_label", product.UnitLabel)
+
+	return nil
+}
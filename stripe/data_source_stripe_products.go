@@ -0,0 +1,144 @@
+package stripe
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+func dataSourceStripeProducts() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceStripeProductsRead,
+
+		Schema: map[string]*schema.Schema{
+			"active": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Optional: true,
+			},
+			"limit": dataSourceListLimitSchema(),
+			"products": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"active": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"default_price": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"id": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"currency": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"nickname": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"unit_amount": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+									"unit_amount_decimal": {
+										Type:     schema.TypeFloat,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"default_prices": {
+				Type:     schema.TypeMap,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceStripeProductsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.ProductListParams{}
+	params.Context = ctx
+	// Expanding on the list saves a request per product.
+	params.AddExpand("data.default_price")
+
+	if active, ok := d.GetOkExists("active"); ok {
+		params.Active = stripe.Bool(active.(bool))
+	}
+
+	params.IDs = expandStringList(d, "ids")
+
+	limit := d.Get("limit").(int)
+	setListLimit(&params.ListParams, limit)
+
+	list, err := listAll[*stripe.Product](ctx, client.Products.List(params), limit, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ids := make([]string, 0, len(list))
+	products := make([]map[string]interface{}, 0, len(list))
+	defaultPrices := make(map[string]string)
+	for _, product := range list {
+		ids = append(ids, product.ID)
+		products = append(products, map[string]interface{}{
+			"id":            product.ID,
+			"name":          product.Name,
+			"active":        product.Active,
+			"default_price": flattenProductDefaultPrice(product.DefaultPrice),
+		})
+		if product.DefaultPrice != nil {
+			defaultPrices[product.ID] = product.DefaultPrice.ID
+		}
+	}
+
+	d.SetId(strconv.Itoa(schema.HashString(strings.Join(ids, ","))))
+	d.Set("products", products)
+	d.Set("default_prices", defaultPrices)
+
+	return nil
+}
+
+func flattenProductDefaultPrice(in *stripe.Price) []map[string]interface{} {
+	if in == nil {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{
+		{
+			"id":                  in.ID,
+			"currency":            in.Currency,
+			"nickname":            in.Nickname,
+			"unit_amount":         in.UnitAmount,
+			"unit_amount_decimal": in.UnitAmountDecimal,
+		},
+	}
+}
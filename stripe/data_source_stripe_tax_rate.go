@@ -0,0 +1,83 @@
+package stripe
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+func dataSourceStripeTaxRate() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceStripeTaxRateRead,
+
+		Schema: map[string]*schema.Schema{
+			"tax_rate_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"display_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"percentage": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+			"inclusive": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"active": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"jurisdiction": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"country": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"tax_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceStripeTaxRateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+	taxRateID := d.Get("tax_rate_id").(string)
+
+	params := &stripe.TaxRateParams{}
+	params.Context = ctx
+
+	taxRate, err := client.TaxRates.Get(taxRateID, params)
+	if stripeErr, ok := err.(*stripe.Error); ok && stripeErr.HTTPStatusCode == http.StatusNotFound {
+		return diag.Errorf("no tax rate found with id %q", taxRateID)
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(taxRate.ID)
+	d.Set("display_name", taxRate.DisplayName)
+	d.Set("percentage", taxRate.Percentage)
+	d.Set("inclusive", taxRate.Inclusive)
+	d.Set("active", taxRate.Active)
+	d.Set("jurisdiction", taxRate.Jurisdiction)
+	d.Set("country", taxRate.Country)
+	d.Set("state", taxRate.State)
+	d.Set("tax_type", taxRate.TaxType)
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package stripe
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+func dataSourceStripeTaxRates() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceStripeTaxRatesRead,
+
+		Schema: map[string]*schema.Schema{
+			"country": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"limit": dataSourceListLimitSchema(),
+			"tax_rates": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"country": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"display_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"inclusive": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"jurisdiction": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"percentage": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+						"state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceStripeTaxRatesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+	country := d.Get("country").(string)
+	state := d.Get("state").(string)
+
+	params := &stripe.TaxRateListParams{
+		Active: stripe.Bool(true),
+	}
+	params.Context = ctx
+
+	// Stripe can't filter tax rates by location, so they're filtered here.
+	list, err := listAll(ctx, client.TaxRates.List(params), d.Get("limit").(int), func(tax *stripe.TaxRate) bool {
+		return (country == "" || strings.EqualFold(tax.Country, country)) &&
+			(state == "" || strings.EqualFold(tax.State, state))
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ids := make([]string, 0, len(list))
+	taxRates := make([]map[string]interface{}, 0, len(list))
+	for _, tax := range list {
+		ids = append(ids, tax.ID)
+		taxRates = append(taxRates, map[string]interface{}{
+			"id":           tax.ID,
+			"country":      tax.Country,
+			"display_name": tax.DisplayName,
+			"inclusive":    tax.Inclusive,
+			"jurisdiction": tax.Jurisdiction,
+			"percentage":   tax.Percentage,
+			"state":        tax.State,
+		})
+	}
+
+	if len(taxRates) == 0 {
+		return diag.Errorf("no active tax rate matches country %q and state %q", country, state)
+	}
+
+	d.SetId(strconv.Itoa(schema.HashString(strings.Join(ids, ","))))
+	d.Set("tax_rates", taxRates)
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package stripe
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+func dataSourceStripeUsageRecordSummary() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceStripeUsageRecordSummaryRead,
+
+		Schema: map[string]*schema.Schema{
+			"subscription_item": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"limit": dataSourceListLimitSchema(),
+			"summaries": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"invoice": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"period": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"start": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+									"end": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+								},
+							},
+						},
+						"total_usage": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceStripeUsageRecordSummaryRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+	subscriptionItem := d.Get("subscription_item").(string)
+
+	params := &stripe.UsageRecordSummaryListParams{
+		SubscriptionItem: stripe.String(subscriptionItem),
+	}
+	params.Context = ctx
+
+	limit := d.Get("limit").(int)
+	setListLimit(&params.ListParams, limit)
+
+	list, err := listAll[*stripe.UsageRecordSummary](ctx, client.UsageRecordSummaries.List(params), limit, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	summaries := make([]map[string]interface{}, 0, len(list))
+	for _, summary := range list {
+		summaries = append(summaries, flattenUsageRecordSummary(summary))
+	}
+
+	d.SetId(subscriptionItem)
+	d.Set("summaries", summaries)
+
+	return nil
+}
+
+func flattenUsageRecordSummary(in *stripe.UsageRecordSummary) map[string]interface{} {
+	out := map[string]interface{}{
+		"id":          in.ID,
+		"invoice":     in.Invoice,
+		"period":      []map[string]interface{}{},
+		"total_usage": in.TotalUsage,
+	}
+
+	if in.Period != nil {
+		out["period"] = []map[string]interface{}{
+			{
+				"start": in.Period.Start,
+				"end":   in.Period.End,
+			},
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,55 @@
+package stripe
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	stripe "github.com/stripe/stripe-go/v72"
+	"github.com/stripe/stripe-go/v72/client"
+)
+
+// testProviderMeta returns a provider meta whose client sends its requests to
+// handler instead of Stripe.
+func testProviderMeta(t *testing.T, handler http.HandlerFunc) *providerMeta {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	maxNetworkRetries := int64(0)
+	backend := stripe.GetBackendWithConfig(stripe.APIBackend, &stripe.BackendConfig{
+		URL:               stripe.String(server.URL),
+		MaxNetworkRetries: &maxNetworkRetries,
+		LeveledLogger:     &stripe.LeveledLogger{Level: stripe.LevelNull},
+	})
+
+	return &providerMeta{
+		API: client.New("sk_test_123", &stripe.Backends{
+			API:     backend,
+			Connect: backend,
+			Uploads: backend,
+		}),
+	}
+}
+
+// testResourceCreateData returns the data a resource is created from with
+// config, defaults included.
+func testResourceCreateData(t *testing.T, r *schema.Resource, config map[string]interface{}) *schema.ResourceData {
+	t.Helper()
+
+	diff, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := schema.InternalMap(r.Schema).Data(nil, diff)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return d
+}
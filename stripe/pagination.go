@@ -0,0 +1,63 @@
+package stripe
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+// Stripe doesn't return more than 100 objects per page.
+const maxPageSize = 100
+
+// listIterator is implemented by the iterators of every stripe-go list
+// endpoint, which fetch the following pages while has_more is set.
+type listIterator interface {
+	Next() bool
+	Current() interface{}
+	Err() error
+}
+
+// listAll collects the objects of a list iterator that keep accepts (all of
+// them when keep is nil), stopping after limit of them unless it is 0.
+func listAll[T any](ctx context.Context, i listIterator, limit int, keep func(T) bool) ([]T, error) {
+	out := make([]T, 0)
+	for limit == 0 || len(out) < limit {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if !i.Next() {
+			break
+		}
+
+		v := i.Current().(T)
+		if keep == nil || keep(v) {
+			out = append(out, v)
+		}
+	}
+	if err := i.Err(); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// setListLimit avoids fetching full pages when fewer objects are needed. It
+// has to be called before creating the iterator, which copies the params.
+func setListLimit(params *stripe.ListParams, limit int) {
+	if limit > 0 && limit < maxPageSize {
+		params.Limit = stripe.Int64(int64(limit))
+	}
+}
+
+// dataSourceListLimitSchema caps the results of list data sources, they
+// return all of them when it's 0.
+func dataSourceListLimitSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Default:      0,
+		ValidateFunc: validation.IntAtLeast(0),
+	}
+}
@@ -0,0 +1,115 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+// testProductPages serves 3 pages of 2 products, the odd ones being active.
+func testProductPages(t *testing.T, requests *int) *providerMeta {
+	return testProviderMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		if r.URL.Path != "/v1/products" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+
+		first := 1
+		if after := r.URL.Query().Get("starting_after"); after != "" {
+			fmt.Sscanf(after, "prod_%d", &first)
+			first++
+		}
+
+		products := make([]string, 0, 2)
+		for i := first; i < first+2; i++ {
+			products = append(products, fmt.Sprintf(`{"id": "prod_%d", "object": "product", "active": %t}`, i, i%2 == 1))
+		}
+		fmt.Fprintf(w, `{"object": "list", "has_more": %t, "data": [%s]}`, first < 5, strings.Join(products, ","))
+	})
+}
+
+func TestListAll(t *testing.T) {
+	cases := []struct {
+		name     string
+		limit    int
+		keep     func(*stripe.Product) bool
+		want     []string
+		requests int
+	}{
+		{
+			name:     "all pages",
+			want:     []string{"prod_1", "prod_2", "prod_3", "prod_4", "prod_5", "prod_6"},
+			requests: 3,
+		},
+		{
+			name:     "limited",
+			limit:    3,
+			want:     []string{"prod_1", "prod_2", "prod_3"},
+			requests: 2,
+		},
+		{
+			name:     "filtered",
+			keep:     func(p *stripe.Product) bool { return p.Active },
+			want:     []string{"prod_1", "prod_3", "prod_5"},
+			requests: 3,
+		},
+		{
+			name:     "filtered and limited",
+			limit:    2,
+			keep:     func(p *stripe.Product) bool { return p.Active },
+			want:     []string{"prod_1", "prod_3"},
+			requests: 2,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			requests := 0
+			m := testProductPages(t, &requests)
+
+			params := &stripe.ProductListParams{}
+			products, err := listAll[*stripe.Product](context.Background(), m.Products.List(params), tc.limit, tc.keep)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := make([]string, 0, len(products))
+			for _, p := range products {
+				got = append(got, p.ID)
+			}
+			if strings.Join(got, ",") != strings.Join(tc.want, ",") {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+			if requests != tc.requests {
+				t.Errorf("expected %d requests, got %d", tc.requests, requests)
+			}
+		})
+	}
+}
+
+func TestListAllCanceled(t *testing.T) {
+	requests := 0
+	m := testProductPages(t, &requests)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := listAll[*stripe.Product](ctx, m.Products.List(&stripe.ProductListParams{}), 0, nil); err != context.Canceled {
+		t.Errorf("expected the listing to be canceled, got %v", err)
+	}
+}
+
+func TestListAllError(t *testing.T) {
+	m := testProviderMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"error": {"type": "invalid_request_error", "message": "Invalid API Key provided"}}`)
+	})
+
+	if _, err := listAll[*stripe.Product](context.Background(), m.Products.List(&stripe.ProductListParams{}), 0, nil); err == nil || !strings.Contains(err.Error(), "Invalid API Key") {
+		t.Errorf("expected the API error, got %v", err)
+	}
+}
@@ -1,9 +1,13 @@
 package stripe
 
 import (
+	"context"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	stripe "github.com/stripe/stripe-go/v72"
 )
 
 func Provider() *schema.Provider {
@@ -14,26 +18,122 @@ func Provider() *schema.Provider {
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("STRIPE_API_TOKEN", nil),
 			},
+			"api_version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("STRIPE_API_VERSION", stripe.APIVersion),
+			},
+			"api_base_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+			"stripe_account": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"max_network_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"rate_limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"use_idempotency_keys": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"expected_livemode": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+		},
+
+		DataSourcesMap: map[string]*schema.Resource{
+			"stripe_account":              dataSourceStripeAccount(),
+			"stripe_balance":              dataSourceStripeBalance(),
+			"stripe_connection":           dataSourceStripeConnection(),
+			"stripe_coupon":               dataSourceStripeCoupon(),
+			"stripe_price":                dataSourceStripePrice(),
+			"stripe_prices":               dataSourceStripePrices(),
+			"stripe_product":              dataSourceStripeProduct(),
+			"stripe_products":             dataSourceStripeProducts(),
+			"stripe_tax_rate":             dataSourceStripeTaxRate(),
+			"stripe_tax_rates":            dataSourceStripeTaxRates(),
+			"stripe_usage_record_summary": dataSourceStripeUsageRecordSummary(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"stripe_coupon":           resourceStripeCoupon(),
-			"stripe_plan":             resourceStripePlan(),
-			"stripe_price":            resourceStripePrice(),
-			"stripe_product":          resourceStripeProduct(),
-			"stripe_tax_rate":         resourceStripeTaxRate(),
-			"stripe_webhook_endpoint": resourceStripeWebhookEndpoint(),
+			"stripe_account":                      withIdempotencyKey(resourceStripeAccount()),
+			"stripe_account_link":                 resourceStripeAccountLink(),
+			"stripe_apple_pay_domain":             withIdempotencyKey(resourceStripeApplePayDomain()),
+			"stripe_billing_portal_configuration": withIdempotencyKey(resourceStripeBillingPortalConfiguration()),
+			"stripe_coupon":                       withIdempotencyKey(resourceStripeCoupon()),
+			"stripe_credit_note":                  withIdempotencyKey(resourceStripeCreditNote()),
+			"stripe_customer":                     withIdempotencyKey(resourceStripeCustomer()),
+			"stripe_customer_balance_transaction": resourceStripeCustomerBalanceTransaction(),
+			"stripe_customer_tax_id":              withIdempotencyKey(resourceStripeCustomerTaxID()),
+			"stripe_file":                         resourceStripeFile(),
+			"stripe_file_link":                    withIdempotencyKey(resourceStripeFileLink()),
+			"stripe_invoice":                      withIdempotencyKey(resourceStripeInvoice()),
+			"stripe_invoice_item":                 withIdempotencyKey(resourceStripeInvoiceItem()),
+			"stripe_payment_link":                 withIdempotencyKey(resourceStripePaymentLink()),
+			"stripe_plan":                         withIdempotencyKey(resourceStripePlan()),
+			"stripe_price":                        withIdempotencyKey(resourceStripePrice()),
+			"stripe_product":                      withIdempotencyKey(resourceStripeProduct()),
+			"stripe_promotion_code":               withIdempotencyKey(resourceStripePromotionCode()),
+			"stripe_quote":                        withIdempotencyKey(resourceStripeQuote()),
+			"stripe_radar_value_list":             withIdempotencyKey(resourceStripeRadarValueList()),
+			"stripe_radar_value_list_item":        withIdempotencyKey(resourceStripeRadarValueListItem()),
+			"stripe_shipping_rate":                withIdempotencyKey(resourceStripeShippingRate()),
+			"stripe_subscription":                 withIdempotencyKey(resourceStripeSubscription()),
+			"stripe_subscription_schedule":        withIdempotencyKey(resourceStripeSubscriptionSchedule()),
+			"stripe_tax_rate":                     withIdempotencyKey(resourceStripeTaxRate()),
+			"stripe_terminal_configuration":       withIdempotencyKey(resourceStripeTerminalConfiguration()),
+			"stripe_terminal_location":            withIdempotencyKey(resourceStripeTerminalLocation()),
+			"stripe_terminal_reader":              withIdempotencyKey(resourceStripeTerminalReader()),
+			"stripe_webhook_endpoint":             withIdempotencyKey(resourceStripeWebhookEndpoint()),
 		},
 
-		ConfigureFunc: providerConfigure,
+		ConfigureContextFunc: providerConfigure,
 	}
 }
 
-func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	config := Config{
-		APIToken: d.Get("api_token").(string),
+		APIToken:           d.Get("api_token").(string),
+		APIVersion:         d.Get("api_version").(string),
+		APIBaseURL:         d.Get("api_base_url").(string),
+		StripeAccount:      d.Get("stripe_account").(string),
+		MaxNetworkRetries:  int64(d.Get("max_network_retries").(int)),
+		RateLimit:          d.Get("rate_limit").(int),
+		UseIdempotencyKeys: d.Get("use_idempotency_keys").(bool),
+	}
+
+	if expectedLivemode, ok := d.GetOkExists("expected_livemode"); ok {
+		config.ExpectedLivemode = stripe.Bool(expectedLivemode.(bool))
 	}
 
 	log.Println("[INFO] Initializing Stripe client")
-	return config.Client()
+	client, err := config.Client(ctx)
+	if err != nil {
+		return nil, diag.Diagnostics{
+			{
+				Severity: diag.Error,
+				Summary:  "Invalid Stripe provider configuration",
+				Detail:   err.Error(),
+			},
+		}
+	}
+
+	return &providerMeta{
+		API:                client,
+		useIdempotencyKeys: config.UseIdempotencyKeys,
+	}, nil
 }
@@ -0,0 +1,84 @@
+package stripe
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+func TestProvider(t *testing.T) {
+	if err := Provider().InternalValidate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func testPlannedIdempotencyKey(t *testing.T, r *schema.Resource, state *terraform.InstanceState, config map[string]interface{}, m *providerMeta) string {
+	t.Helper()
+
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	params := &stripe.ProductParams{}
+	setIdempotencyKey(params, d)
+	if params.IdempotencyKey == nil {
+		return ""
+	}
+	return *params.IdempotencyKey
+}
+
+func TestIdempotencyKeyPerInstance(t *testing.T) {
+	r := Provider().ResourcesMap["stripe_product"]
+	config := map[string]interface{}{"name": "Seat"}
+	m := &providerMeta{useIdempotencyKeys: true}
+
+	first := testPlannedIdempotencyKey(t, r, nil, config, m)
+	second := testPlannedIdempotencyKey(t, r, nil, config, m)
+
+	if first == "" || second == "" {
+		t.Fatalf("expected idempotency keys, got %q and %q", first, second)
+	}
+	if first == second {
+		t.Errorf("expected instances with the same arguments to get different keys, both got %q", first)
+	}
+}
+
+func TestIdempotencyKeyOnReplace(t *testing.T) {
+	r := Provider().ResourcesMap["stripe_price"]
+	m := &providerMeta{useIdempotencyKeys: true}
+
+	d := r.TestResourceData()
+	d.SetId("price_123")
+	d.Set("currency", "usd")
+	d.Set("product", "prod_123")
+	d.Set("unit_amount", 100)
+	d.Set("active", true)
+	d.Set("idempotency_key", "previous")
+
+	key := testPlannedIdempotencyKey(t, r, d.State(), map[string]interface{}{
+		"currency":    "eur",
+		"product":     "prod_123",
+		"unit_amount": 100,
+	}, m)
+
+	if key == "" || key == "terraform-previous" {
+		t.Errorf("expected the replacement to plan a new key, got %q", key)
+	}
+}
+
+func TestIdempotencyKeyDisabled(t *testing.T) {
+	r := Provider().ResourcesMap["stripe_product"]
+
+	if key := testPlannedIdempotencyKey(t, r, nil, map[string]interface{}{"name": "Seat"}, &providerMeta{}); key != "" {
+		t.Errorf("expected no idempotency key, got %q", key)
+	}
+}
@@ -0,0 +1,574 @@
+package stripe
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	stripe "github.com/stripe/stripe-go/v72"
+	"github.com/stripe/stripe-go/v72/client"
+)
+
+// accountPollInterval is how often capabilities are checked while waiting for
+// them.
+var accountPollInterval = 5 * time.Second
+
+func resourceStripeAccount() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceStripeAccountCreate,
+		ReadContext:   resourceStripeAccountRead,
+		UpdateContext: resourceStripeAccountUpdate,
+		DeleteContext: resourceStripeAccountDelete,
+		// Accounts don't tell their mode, so it can't be checked on import.
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateEnum("standard", "express", "custom"),
+			},
+			"country": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"email": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"business_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateEnum("individual", "company", "non_profit", "government_entity"),
+			},
+			// Requested capabilities, e.g. card_payments or transfers.
+			"capabilities": {
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Optional: true,
+				Computed: true,
+			},
+			"business_profile": {
+				Type: schema.TypeList,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"mcc": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+						"product_description": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+						"support_email": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+						"support_phone": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+						"support_url": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+						"url": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+					},
+				},
+				MaxItems: 1,
+				Optional: true,
+				Computed: true,
+			},
+			"settings": {
+				Type: schema.TypeList,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"branding": {
+							Type: schema.TypeList,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									// File IDs, see stripe_file.
+									"icon": {
+										Type:     schema.TypeString,
+										Optional: true,
+										Computed: true,
+									},
+									"logo": {
+										Type:     schema.TypeString,
+										Optional: true,
+										Computed: true,
+									},
+									"primary_color": {
+										Type:     schema.TypeString,
+										Optional: true,
+										Computed: true,
+									},
+									"secondary_color": {
+										Type:     schema.TypeString,
+										Optional: true,
+										Computed: true,
+									},
+								},
+							},
+							MaxItems: 1,
+							Optional: true,
+							Computed: true,
+						},
+						"payments": {
+							Type: schema.TypeList,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"statement_descriptor": {
+										Type:     schema.TypeString,
+										Optional: true,
+										Computed: true,
+									},
+								},
+							},
+							MaxItems: 1,
+							Optional: true,
+							Computed: true,
+						},
+						"payouts": {
+							Type: schema.TypeList,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"statement_descriptor": {
+										Type:     schema.TypeString,
+										Optional: true,
+										Computed: true,
+									},
+									"debit_negative_balances": {
+										Type:     schema.TypeBool,
+										Optional: true,
+										Computed: true,
+									},
+								},
+							},
+							MaxItems: 1,
+							Optional: true,
+							Computed: true,
+						},
+					},
+				},
+				MaxItems: 1,
+				Optional: true,
+				Computed: true,
+			},
+			"metadata": {
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional: true,
+			},
+			// Capabilities are verified asynchronously, applies can wait until
+			// all of them are active.
+			"wait_for_status": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateEnum("active"),
+			},
+			// Computed
+			"capability_statuses": {
+				Type:     schema.TypeMap,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
+			"charges_enabled": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"payouts_enabled": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"details_submitted": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"created": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// stringParam returns nil for empty strings, which Stripe would otherwise
+// take as unsetting the field.
+func stringParam(in interface{}) *string {
+	if s, _ := in.(string); s != "" {
+		return stripe.String(s)
+	}
+	return nil
+}
+
+func expandAccountBusinessProfile(in []interface{}) *stripe.AccountBusinessProfileParams {
+	if len(in) == 0 || in[0] == nil {
+		return nil
+	}
+
+	profile := in[0].(map[string]interface{})
+	return &stripe.AccountBusinessProfileParams{
+		MCC:                stringParam(profile["mcc"]),
+		Name:               stringParam(profile["name"]),
+		ProductDescription: stringParam(profile["product_description"]),
+		SupportEmail:       stringParam(profile["support_email"]),
+		SupportPhone:       stringParam(profile["support_phone"]),
+		SupportURL:         stringParam(profile["support_url"]),
+		URL:                stringParam(profile["url"]),
+	}
+}
+
+func flattenAccountBusinessProfile(in *stripe.AccountBusinessProfile) []map[string]interface{} {
+	if in == nil {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{
+		{
+			"mcc":                 in.MCC,
+			"name":                in.Name,
+			"product_description": in.ProductDescription,
+			"support_email":       in.SupportEmail,
+			"support_phone":       in.SupportPhone,
+			"support_url":         in.SupportURL,
+			"url":                 in.URL,
+		},
+	}
+}
+
+func expandAccountSettings(in []interface{}) *stripe.AccountSettingsParams {
+	if len(in) == 0 || in[0] == nil {
+		return nil
+	}
+
+	settings := in[0].(map[string]interface{})
+	params := &stripe.AccountSettingsParams{}
+
+	if branding := settings["branding"].([]interface{}); len(branding) > 0 && branding[0] != nil {
+		b := branding[0].(map[string]interface{})
+		params.Branding = &stripe.AccountSettingsBrandingParams{
+			Icon:           stringParam(b["icon"]),
+			Logo:           stringParam(b["logo"]),
+			PrimaryColor:   stringParam(b["primary_color"]),
+			SecondaryColor: stringParam(b["secondary_color"]),
+		}
+	}
+
+	if payments := settings["payments"].([]interface{}); len(payments) > 0 && payments[0] != nil {
+		p := payments[0].(map[string]interface{})
+		params.Payments = &stripe.AccountSettingsPaymentsParams{
+			StatementDescriptor: stringParam(p["statement_descriptor"]),
+		}
+	}
+
+	if payouts := settings["payouts"].([]interface{}); len(payouts) > 0 && payouts[0] != nil {
+		p := payouts[0].(map[string]interface{})
+		params.Payouts = &stripe.AccountSettingsPayoutsParams{
+			StatementDescriptor:   stringParam(p["statement_descriptor"]),
+			DebitNegativeBalances: stripe.Bool(p["debit_negative_balances"].(bool)),
+		}
+	}
+
+	return params
+}
+
+func flattenAccountSettings(in *stripe.AccountSettings) []map[string]interface{} {
+	if in == nil {
+		return []map[string]interface{}{}
+	}
+
+	settings := map[string]interface{}{}
+
+	if in.Branding != nil {
+		branding := map[string]interface{}{
+			"primary_color":   in.Branding.PrimaryColor,
+			"secondary_color": in.Branding.SecondaryColor,
+		}
+		if in.Branding.Icon != nil {
+			branding["icon"] = in.Branding.Icon.ID
+		}
+		if in.Branding.Logo != nil {
+			branding["logo"] = in.Branding.Logo.ID
+		}
+		settings["branding"] = []map[string]interface{}{branding}
+	}
+
+	if in.Payments != nil {
+		settings["payments"] = []map[string]interface{}{
+			{
+				"statement_descriptor": in.Payments.StatementDescriptor,
+			},
+		}
+	}
+
+	if in.Payouts != nil {
+		settings["payouts"] = []map[string]interface{}{
+			{
+				"statement_descriptor":    in.Payouts.StatementDescriptor,
+				"debit_negative_balances": in.Payouts.DebitNegativeBalances,
+			},
+		}
+	}
+
+	return []map[string]interface{}{settings}
+}
+
+// expandAccountCapabilities requests the configured capabilities, and stops
+// requesting the ones removed from the configuration.
+func expandAccountCapabilities(d *schema.ResourceData, params *stripe.AccountParams) {
+	o, n := d.GetChange("capabilities")
+
+	for _, capability := range n.(*schema.Set).Difference(o.(*schema.Set)).List() {
+		params.AddExtra(fmt.Sprintf("capabilities[%s][requested]", capability), "true")
+	}
+
+	for _, capability := range o.(*schema.Set).Difference(n.(*schema.Set)).List() {
+		params.AddExtra(fmt.Sprintf("capabilities[%s][requested]", capability), "false")
+	}
+}
+
+func resourceStripeAccountCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+	accountType := d.Get("type").(string)
+
+	params := &stripe.AccountParams{
+		Type: stripe.String(accountType),
+	}
+	params.Context = ctx
+
+	if country, ok := d.GetOk("country"); ok {
+		params.Country = stripe.String(country.(string))
+	}
+
+	if email, ok := d.GetOk("email"); ok {
+		params.Email = stripe.String(email.(string))
+	}
+
+	if businessType, ok := d.GetOk("business_type"); ok {
+		params.BusinessType = stripe.String(businessType.(string))
+	}
+
+	params.BusinessProfile = expandAccountBusinessProfile(d.Get("business_profile").([]interface{}))
+	params.Settings = expandAccountSettings(d.Get("settings").([]interface{}))
+	expandAccountCapabilities(d, params)
+
+	metadata, diags := expandMetadata(d)
+	if diags.HasError() {
+		return diags
+	}
+	params.Metadata = metadata
+
+	setIdempotencyKey(params, d)
+	account, err := client.Account.New(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Create %s account: %s", accountType, account.ID)
+	d.SetId(account.ID)
+
+	if status, ok := d.GetOk("wait_for_status"); ok {
+		if err := waitForAccountCapabilities(ctx, client, account.ID, status.(string), d.Timeout(schema.TimeoutCreate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceStripeAccountRead(ctx, d, m)
+}
+
+// readAccountCapabilities returns the status of every capability of the
+// account. stripe-go only knows a fixed list of capabilities, they're read
+// from the raw response instead.
+func readAccountCapabilities(account *stripe.Account) (map[string]string, error) {
+	var raw struct {
+		Capabilities map[string]string `json:"capabilities"`
+	}
+	if err := json.Unmarshal(account.LastResponse.RawJSON, &raw); err != nil {
+		return nil, err
+	}
+
+	return raw.Capabilities, nil
+}
+
+// accountCapabilitiesRefreshFunc reports the account as pending until all of
+// its capabilities are active, and as inactive as soon as one of them needs
+// more information.
+func accountCapabilitiesRefreshFunc(ctx context.Context, client *client.API, id string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		params := &stripe.AccountParams{}
+		params.Context = ctx
+
+		account, err := client.Account.GetByID(id, params)
+		if err != nil {
+			return nil, "", err
+		}
+
+		capabilities, err := readAccountCapabilities(account)
+		if err != nil {
+			return nil, "", err
+		}
+
+		state := "active"
+		for _, status := range capabilities {
+			switch status {
+			case "inactive":
+				return account, status, nil
+			case "pending":
+				state = status
+			}
+		}
+
+		return account, state, nil
+	}
+}
+
+func waitForAccountCapabilities(ctx context.Context, client *client.API, id, status string, timeout time.Duration) error {
+	log.Printf("[DEBUG] Waiting for the capabilities of account %s to be %s", id, status)
+
+	conf := &resource.StateChangeConf{
+		Pending:      []string{"pending"},
+		Target:       []string{status},
+		Refresh:      accountCapabilitiesRefreshFunc(ctx, client, id),
+		Timeout:      timeout,
+		PollInterval: accountPollInterval,
+	}
+
+	if _, err := conf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("waiting for the capabilities of account %s to be %s: %s", id, status, err)
+	}
+
+	return nil
+}
+
+func resourceStripeAccountRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.AccountParams{}
+	params.Context = ctx
+
+	account, err := client.Account.GetByID(d.Id(), params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	capabilityStatuses, err := readAccountCapabilities(account)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	capabilities := make([]string, 0, len(capabilityStatuses))
+	for capability := range capabilityStatuses {
+		capabilities = append(capabilities, capability)
+	}
+
+	d.Set("type", account.Type)
+	d.Set("country", account.Country)
+	d.Set("email", account.Email)
+	d.Set("business_type", account.BusinessType)
+	d.Set("capabilities", capabilities)
+	d.Set("capability_statuses", capabilityStatuses)
+	d.Set("business_profile", flattenAccountBusinessProfile(account.BusinessProfile))
+	d.Set("settings", flattenAccountSettings(account.Settings))
+	d.Set("metadata", account.Metadata)
+	d.Set("charges_enabled", account.ChargesEnabled)
+	d.Set("payouts_enabled", account.PayoutsEnabled)
+	d.Set("details_submitted", account.DetailsSubmitted)
+	d.Set("created", account.Created)
+
+	return nil
+}
+
+func resourceStripeAccountUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.AccountParams{}
+	params.Context = ctx
+
+	if d.HasChange("email") {
+		params.Email = stripe.String(d.Get("email").(string))
+	}
+
+	if d.HasChange("business_type") {
+		params.BusinessType = stripe.String(d.Get("business_type").(string))
+	}
+
+	if d.HasChange("capabilities") {
+		expandAccountCapabilities(d, params)
+	}
+
+	if d.HasChange("business_profile") {
+		params.BusinessProfile = expandAccountBusinessProfile(d.Get("business_profile").([]interface{}))
+	}
+
+	if d.HasChange("settings") {
+		params.Settings = expandAccountSettings(d.Get("settings").([]interface{}))
+	}
+
+	if d.HasChange("metadata") {
+		metadata, diags := expandMetadata(d)
+		if diags.HasError() {
+			return diags
+		}
+		params.Metadata = metadata
+	}
+
+	if _, err := client.Account.Update(d.Id(), params); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if status, ok := d.GetOk("wait_for_status"); ok && d.HasChanges("capabilities", "wait_for_status") {
+		if err := waitForAccountCapabilities(ctx, client, d.Id(), status.(string), d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceStripeAccountRead(ctx, d, m)
+}
+
+func resourceStripeAccountDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.AccountParams{}
+	params.Context = ctx
+
+	if _, err := client.Account.Del(d.Id(), params); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+// Account links are single-use URLs expiring after a few minutes. Stripe
+// can't retrieve them, so they only live in the state and a new one is
+// created once the previous one expired.
+func resourceStripeAccountLink() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceStripeAccountLinkCreate,
+		ReadContext:   resourceStripeAccountLinkRead,
+		DeleteContext: resourceStripeAccountLinkDelete,
+
+		Schema: map[string]*schema.Schema{
+			"account": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"refresh_url": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+			"return_url": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateEnum("account_onboarding", "account_update"),
+			},
+			"collect": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateEnum("currently_due", "eventually_due"),
+			},
+			// Computed
+			"url": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"expires_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"created": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceStripeAccountLinkCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+	account := d.Get("account").(string)
+
+	params := &stripe.AccountLinkParams{
+		Account:    stripe.String(account),
+		RefreshURL: stripe.String(d.Get("refresh_url").(string)),
+		ReturnURL:  stripe.String(d.Get("return_url").(string)),
+		Type:       stripe.String(d.Get("type").(string)),
+	}
+	params.Context = ctx
+
+	if collect, ok := d.GetOk("collect"); ok {
+		params.Collect = stripe.String(collect.(string))
+	}
+
+	// No idempotency key is derived here: the same parameters have to give a
+	// new link once the previous one expired.
+	link, err := client.AccountLinks.New(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Create account link for %s", account)
+	d.SetId(fmt.Sprintf("%s/%d", account, link.Created))
+	d.Set("url", link.URL)
+	d.Set("expires_at", time.Unix(link.ExpiresAt, 0).UTC().Format(time.RFC3339))
+	d.Set("created", link.Created)
+
+	return nil
+}
+
+func resourceStripeAccountLinkRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	expiresAt, err := time.Parse(time.RFC3339, d.Get("expires_at").(string))
+	if err != nil || time.Now().After(expiresAt) {
+		log.Printf("[INFO] Account link %s expired, removing it from state", d.Id())
+		d.SetId("")
+	}
+
+	return nil
+}
+
+func resourceStripeAccountLinkDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testAccountCapabilitiesHandler(t *testing.T, statuses ...string) (http.HandlerFunc, *int) {
+	polls := 0
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/accounts/acct_123" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		status := statuses[len(statuses)-1]
+		if polls < len(statuses) {
+			status = statuses[polls]
+		}
+		polls++
+
+		fmt.Fprintf(w, `{"id": "acct_123", "object": "account", "capabilities": {"card_payments": "active", "transfers": %q}}`, status)
+	}, &polls
+}
+
+func TestWaitForAccountCapabilities(t *testing.T) {
+	accountPollInterval = time.Millisecond
+
+	handler, polls := testAccountCapabilitiesHandler(t, "pending", "pending", "pending", "active")
+	m := testProviderMeta(t, handler)
+
+	if err := waitForAccountCapabilities(context.Background(), m.API, "acct_123", "active", time.Minute); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if *polls != 4 {
+		t.Errorf("expected 4 polls, got %d", *polls)
+	}
+}
+
+func TestWaitForAccountCapabilitiesInactive(t *testing.T) {
+	accountPollInterval = time.Millisecond
+
+	handler, _ := testAccountCapabilitiesHandler(t, "pending", "inactive")
+	m := testProviderMeta(t, handler)
+
+	err := waitForAccountCapabilities(context.Background(), m.API, "acct_123", "active", time.Minute)
+	if err == nil || !strings.Contains(err.Error(), "inactive") {
+		t.Fatalf("expected an inactive capability error, got %v", err)
+	}
+}
+
+func TestWaitForAccountCapabilitiesTimeout(t *testing.T) {
+	accountPollInterval = time.Millisecond
+
+	handler, _ := testAccountCapabilitiesHandler(t, "pending")
+	m := testProviderMeta(t, handler)
+
+	err := waitForAccountCapabilities(context.Background(), m.API, "acct_123", "active", 50*time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "timeout") {
+		t.Fatalf("expected a timeout, got %v", err)
+	}
+}
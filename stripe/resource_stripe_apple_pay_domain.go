@@ -0,0 +1,114 @@
+package stripe
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	stripe "github.com/stripe/stripe-go/v72"
+	"github.com/stripe/stripe-go/v72/client"
+)
+
+// Stripe doesn't allow updating Apple Pay domains, every change recreates them.
+func resourceStripeApplePayDomain() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceStripeApplePayDomainCreate,
+		ReadContext:   resourceStripeApplePayDomainRead,
+		DeleteContext: resourceStripeApplePayDomainDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateCheckingLivemode(resourceStripeApplePayDomainLivemode),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"domain_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			// Computed
+			"created": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"livemode": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceStripeApplePayDomainCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+	domainName := d.Get("domain_name").(string)
+
+	params := &stripe.ApplePayDomainParams{
+		DomainName: stripe.String(domainName),
+	}
+	params.Context = ctx
+
+	setIdempotencyKey(params, d)
+	domain, err := client.ApplePayDomains.New(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Create Apple Pay domain: %s (%s)", domain.ID, domainName)
+	d.SetId(domain.ID)
+
+	return resourceStripeApplePayDomainRead(ctx, d, m)
+}
+
+func resourceStripeApplePayDomainLivemode(ctx context.Context, client *client.API, id string) (bool, error) {
+	params := &stripe.ApplePayDomainParams{}
+	params.Context = ctx
+
+	domain, err := client.ApplePayDomains.Get(id, params)
+	if err != nil {
+		return false, err
+	}
+
+	return domain.Livemode, nil
+}
+
+func resourceStripeApplePayDomainRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.ApplePayDomainParams{}
+	params.Context = ctx
+
+	domain, err := client.ApplePayDomains.Get(d.Id(), params)
+	if err != nil {
+		// The domain is no longer registered, e.g. it was removed from the
+		// dashboard, so it has to be registered again.
+		if stripeErr, ok := err.(*stripe.Error); ok && stripeErr.HTTPStatusCode == http.StatusNotFound {
+			log.Printf("[WARN] Apple Pay domain %s is no longer registered, removing it from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	d.Set("domain_name", domain.DomainName)
+	d.Set("created", domain.Created)
+	d.Set("livemode", domain.Livemode)
+
+	return nil
+}
+
+func resourceStripeApplePayDomainDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.ApplePayDomainParams{}
+	params.Context = ctx
+
+	if _, err := client.ApplePayDomains.Del(d.Id(), params); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
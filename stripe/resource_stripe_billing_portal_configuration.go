@@ -0,0 +1,442 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	stripe "github.com/stripe/stripe-go/v72"
+	"github.com/stripe/stripe-go/v72/client"
+)
+
+func resourceStripeBillingPortalConfiguration() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceStripeBillingPortalConfigurationCreate,
+		ReadContext:   resourceStripeBillingPortalConfigurationRead,
+		UpdateContext: resourceStripeBillingPortalConfigurationUpdate,
+		DeleteContext: resourceStripeBillingPortalConfigurationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateCheckingLivemode(resourceStripeBillingPortalConfigurationLivemode),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"business_profile": {
+				Type: schema.TypeList,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"headline": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"privacy_policy_url": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"terms_of_service_url": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+				MinItems: 1,
+				MaxItems: 1,
+				Required: true,
+			},
+			// Features with settings are enabled by declaring their block,
+			// omitted ones are disabled.
+			"features": {
+				Type: schema.TypeList,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"customer_update": {
+							Type: schema.TypeList,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"allowed_updates": {
+										Type: schema.TypeList,
+										Elem: &schema.Schema{
+											Type:         schema.TypeString,
+											ValidateFunc: validateEnum("email", "address", "shipping", "phone", "tax_id"),
+										},
+										Required: true,
+									},
+								},
+							},
+							MaxItems: 1,
+							Optional: true,
+						},
+						"invoice_history": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"payment_method_update": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"subscription_cancel": {
+							Type: schema.TypeList,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"mode": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										Default:      "at_period_end",
+										ValidateFunc: validateEnum("at_period_end", "immediately"),
+									},
+									"proration_behavior": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										Default:      "none",
+										ValidateFunc: validateEnum("create_prorations", "none", "always_invoice"),
+									},
+								},
+							},
+							MaxItems: 1,
+							Optional: true,
+						},
+						"subscription_update": {
+							Type: schema.TypeList,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"default_allowed_updates": {
+										Type: schema.TypeList,
+										Elem: &schema.Schema{
+											Type:         schema.TypeString,
+											ValidateFunc: validateEnum("price", "quantity", "promotion_code"),
+										},
+										Required: true,
+									},
+									"proration_behavior": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										Default:      "none",
+										ValidateFunc: validateEnum("create_prorations", "none", "always_invoice"),
+									},
+									"products": {
+										Type: schema.TypeList,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"product": {
+													Type:     schema.TypeString,
+													Required: true,
+												},
+												"prices": {
+													Type: schema.TypeList,
+													Elem: &schema.Schema{
+														Type: schema.TypeString,
+													},
+													MinItems: 1,
+													Required: true,
+												},
+											},
+										},
+										MinItems: 1,
+										Required: true,
+									},
+								},
+							},
+							MaxItems: 1,
+							Optional: true,
+						},
+					},
+				},
+				MinItems: 1,
+				MaxItems: 1,
+				Required: true,
+			},
+			"default_return_url": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"metadata": {
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional: true,
+			},
+			// Computed
+			"active": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"is_default": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"created": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"livemode": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func expandBillingPortalConfigurationBusinessProfile(in []interface{}) *stripe.BillingPortalConfigurationBusinessProfileParams {
+	out := &stripe.BillingPortalConfigurationBusinessProfileParams{}
+	if len(in) == 0 || in[0] == nil {
+		return out
+	}
+
+	businessProfile := in[0].(map[string]interface{})
+	out.Headline = stripe.String(businessProfile["headline"].(string))
+	out.PrivacyPolicyURL = stripe.String(businessProfile["privacy_policy_url"].(string))
+	out.TermsOfServiceURL = stripe.String(businessProfile["terms_of_service_url"].(string))
+
+	return out
+}
+
+func expandBillingPortalConfigurationFeatures(d *schema.ResourceData) *stripe.BillingPortalConfigurationFeaturesParams {
+	out := &stripe.BillingPortalConfigurationFeaturesParams{
+		CustomerUpdate: &stripe.BillingPortalConfigurationFeaturesCustomerUpdateParams{
+			Enabled: stripe.Bool(false),
+		},
+		InvoiceHistory: &stripe.BillingPortalConfigurationFeaturesInvoiceHistoryParams{
+			Enabled: stripe.Bool(false),
+		},
+		PaymentMethodUpdate: &stripe.BillingPortalConfigurationFeaturesPaymentMethodUpdateParams{
+			Enabled: stripe.Bool(false),
+		},
+		SubscriptionCancel: &stripe.BillingPortalConfigurationFeaturesSubscriptionCancelParams{
+			Enabled: stripe.Bool(false),
+		},
+		SubscriptionUpdate: &stripe.BillingPortalConfigurationFeaturesSubscriptionUpdateParams{
+			Enabled: stripe.Bool(false),
+		},
+	}
+
+	if _, ok := d.GetOk("features.0.customer_update"); ok {
+		out.CustomerUpdate.Enabled = stripe.Bool(true)
+		out.CustomerUpdate.AllowedUpdates = expandStringList(d, "features.0.customer_update.0.allowed_updates")
+	}
+
+	out.InvoiceHistory.Enabled = stripe.Bool(d.Get("features.0.invoice_history").(bool))
+	out.PaymentMethodUpdate.Enabled = stripe.Bool(d.Get("features.0.payment_method_update").(bool))
+
+	if _, ok := d.GetOk("features.0.subscription_cancel"); ok {
+		out.SubscriptionCancel.Enabled = stripe.Bool(true)
+		out.SubscriptionCancel.Mode = stripe.String(d.Get("features.0.subscription_cancel.0.mode").(string))
+		out.SubscriptionCancel.ProrationBehavior = stripe.String(d.Get("features.0.subscription_cancel.0.proration_behavior").(string))
+	}
+
+	if _, ok := d.GetOk("features.0.subscription_update"); ok {
+		out.SubscriptionUpdate.Enabled = stripe.Bool(true)
+		out.SubscriptionUpdate.DefaultAllowedUpdates = expandStringList(d, "features.0.subscription_update.0.default_allowed_updates")
+		out.SubscriptionUpdate.ProrationBehavior = stripe.String(d.Get("features.0.subscription_update.0.proration_behavior").(string))
+
+		products := d.Get("features.0.subscription_update.0.products").([]interface{})
+		for i := range products {
+			key := fmt.Sprintf("features.0.subscription_update.0.products.%d", i)
+			out.SubscriptionUpdate.Products = append(out.SubscriptionUpdate.Products, &stripe.BillingPortalConfigurationFeaturesSubscriptionUpdateProductParams{
+				Product: stripe.String(d.Get(key + ".product").(string)),
+				Prices:  expandStringList(d, key+".prices"),
+			})
+		}
+	}
+
+	return out
+}
+
+func flattenBillingPortalConfigurationFeatures(in *stripe.BillingPortalConfigurationFeatures) []map[string]interface{} {
+	out := map[string]interface{}{}
+	if in == nil {
+		return []map[string]interface{}{out}
+	}
+
+	if in.CustomerUpdate != nil && in.CustomerUpdate.Enabled {
+		allowedUpdates := make([]string, len(in.CustomerUpdate.AllowedUpdates))
+		for i, update := range in.CustomerUpdate.AllowedUpdates {
+			allowedUpdates[i] = string(update)
+		}
+		out["customer_update"] = []map[string]interface{}{
+			{"allowed_updates": allowedUpdates},
+		}
+	}
+
+	if in.InvoiceHistory != nil {
+		out["invoice_history"] = in.InvoiceHistory.Enabled
+	}
+
+	if in.PaymentMethodUpdate != nil {
+		out["payment_method_update"] = in.PaymentMethodUpdate.Enabled
+	}
+
+	if in.SubscriptionCancel != nil && in.SubscriptionCancel.Enabled {
+		out["subscription_cancel"] = []map[string]interface{}{
+			{
+				"mode":               string(in.SubscriptionCancel.Mode),
+				"proration_behavior": string(in.SubscriptionCancel.ProrationBehavior),
+			},
+		}
+	}
+
+	if in.SubscriptionUpdate != nil && in.SubscriptionUpdate.Enabled {
+		defaultAllowedUpdates := make([]string, len(in.SubscriptionUpdate.DefaultAllowedUpdates))
+		for i, update := range in.SubscriptionUpdate.DefaultAllowedUpdates {
+			defaultAllowedUpdates[i] = string(update)
+		}
+
+		products := make([]map[string]interface{}, 0, len(in.SubscriptionUpdate.Products))
+		for _, product := range in.SubscriptionUpdate.Products {
+			products = append(products, map[string]interface{}{
+				"product": product.Product,
+				"prices":  product.Prices,
+			})
+		}
+
+		out["subscription_update"] = []map[string]interface{}{
+			{
+				"default_allowed_updates": defaultAllowedUpdates,
+				"proration_behavior":      string(in.SubscriptionUpdate.ProrationBehavior),
+				"products":                products,
+			},
+		}
+	}
+
+	return []map[string]interface{}{out}
+}
+
+func resourceStripeBillingPortalConfigurationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.BillingPortalConfigurationParams{
+		BusinessProfile: expandBillingPortalConfigurationBusinessProfile(d.Get("business_profile").([]interface{})),
+		Features:        expandBillingPortalConfigurationFeatures(d),
+	}
+	params.Context = ctx
+
+	if defaultReturnURL, ok := d.GetOk("default_return_url"); ok {
+		params.DefaultReturnURL = stripe.String(defaultReturnURL.(string))
+	}
+
+	metadata, diags := expandMetadata(d)
+	if diags.HasError() {
+		return diags
+	}
+	params.Metadata = metadata
+
+	setIdempotencyKey(params, d)
+	configuration, err := client.BillingPortalConfigurations.New(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Create billing portal configuration: %s", configuration.ID)
+	d.SetId(configuration.ID)
+
+	return resourceStripeBillingPortalConfigurationRead(ctx, d, m)
+}
+
+func resourceStripeBillingPortalConfigurationLivemode(ctx context.Context, client *client.API, id string) (bool, error) {
+	params := &stripe.BillingPortalConfigurationParams{}
+	params.Context = ctx
+
+	configuration, err := client.BillingPortalConfigurations.Get(id, params)
+	if err != nil {
+		return false, err
+	}
+
+	return configuration.Livemode, nil
+}
+
+func resourceStripeBillingPortalConfigurationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.BillingPortalConfigurationParams{}
+	params.Context = ctx
+
+	configuration, err := client.BillingPortalConfigurations.Get(d.Id(), params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if configuration.BusinessProfile != nil {
+		d.Set("business_profile", []map[string]interface{}{
+			{
+				"headline":             configuration.BusinessProfile.Headline,
+				"privacy_policy_url":   configuration.BusinessProfile.PrivacyPolicyURL,
+				"terms_of_service_url": configuration.BusinessProfile.TermsOfServiceURL,
+			},
+		})
+	}
+	d.Set("features", flattenBillingPortalConfigurationFeatures(configuration.Features))
+	d.Set("default_return_url", configuration.DefaultReturnURL)
+	d.Set("metadata", configuration.Metadata)
+	d.Set("active", configuration.Active)
+	d.Set("is_default", configuration.IsDefault)
+	d.Set("created", configuration.Created)
+	d.Set("livemode", configuration.Livemode)
+
+	return nil
+}
+
+func resourceStripeBillingPortalConfigurationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.BillingPortalConfigurationParams{}
+	params.Context = ctx
+
+	if d.HasChange("business_profile") {
+		params.BusinessProfile = expandBillingPortalConfigurationBusinessProfile(d.Get("business_profile").([]interface{}))
+	}
+
+	if d.HasChange("features") {
+		params.Features = expandBillingPortalConfigurationFeatures(d)
+	}
+
+	if d.HasChange("default_return_url") {
+		params.DefaultReturnURL = stripe.String(d.Get("default_return_url").(string))
+	}
+
+	if d.HasChange("metadata") {
+		metadata, diags := expandMetadata(d)
+		if diags.HasError() {
+			return diags
+		}
+		params.Metadata = metadata
+	}
+
+	if _, err := client.BillingPortalConfigurations.Update(d.Id(), params); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStripeBillingPortalConfigurationRead(ctx, d, m)
+}
+
+func resourceStripeBillingPortalConfigurationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	// Stripe neither allows deleting configurations nor deactivating the
+	// default one, which is left untouched.
+	if d.Get("is_default").(bool) {
+		d.SetId("")
+		return diag.Diagnostics{
+			{
+				Severity: diag.Warning,
+				Summary:  "Default billing portal configuration left active",
+				Detail:   "Stripe doesn't allow deactivating the default billing portal configuration, it has only been removed from the state.",
+			},
+		}
+	}
+
+	params := &stripe.BillingPortalConfigurationParams{
+		Active: stripe.Bool(false),
+	}
+	params.Context = ctx
+
+	if _, err := client.BillingPortalConfigurations.Update(d.Id(), params); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
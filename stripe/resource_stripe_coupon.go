@@ -2,8 +2,8 @@ package stripe
 
 import (
 	"context"
+	"fmt"
 	"log"
-	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -19,7 +19,7 @@ func resourceStripeCoupon() *schema.Resource {
 		UpdateContext: resourceStripeCouponUpdate,
 		DeleteContext: resourceStripeCouponDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateCheckingLivemode(resourceStripeCouponLivemode),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -28,19 +28,23 @@ func resourceStripeCoupon() *schema.Resource {
 				Required: true, // require it as the default one is more trouble than it's worth
 			},
 			"amount_off": {
-				Type:     schema.TypeInt,
-				Optional: true,
-				ForceNew: true,
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"percent_off"},
 			},
 			"currency": {
-				Type:     schema.TypeString, // <- check values
-				Optional: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCurrency,
+				StateFunc:    lowercaseString,
 			},
 			"duration": {
-				Type:     schema.TypeString,
-				Required: true, // forever | once | repeating
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateEnum("forever", "once", "repeating"),
 			},
 			"duration_in_months": {
 				Type:     schema.TypeInt,
@@ -63,11 +67,13 @@ func resourceStripeCoupon() *schema.Resource {
 			"name": {
 				Type:     schema.TypeString,
 				Optional: true,
+				Computed: true, // Stripe generates one from the discount when omitted
 			},
 			"percent_off": {
-				Type:     schema.TypeFloat,
-				Optional: true,
-				ForceNew: true,
+				Type:          schema.TypeFloat,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"amount_off"},
 			},
 			"redeem_by": {
 				Type:     schema.TypeString,
@@ -92,11 +98,45 @@ func resourceStripeCoupon() *schema.Resource {
 				Computed: true,
 			},
 		},
+		CustomizeDiff: resourceStripeCouponCustomizeDiff,
+	}
+}
+
+func resourceStripeCouponCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.NewValueKnown("amount_off") && d.NewValueKnown("percent_off") {
+		_, hasAmountOff := d.GetOk("amount_off")
+		_, hasPercentOff := d.GetOk("percent_off")
+		if !hasAmountOff && !hasPercentOff {
+			return fmt.Errorf("one of amount_off or percent_off must be set")
+		}
+
+		if d.NewValueKnown("currency") {
+			_, hasCurrency := d.GetOk("currency")
+			if hasAmountOff && !hasCurrency {
+				return fmt.Errorf("currency: required when amount_off is set")
+			}
+			if hasCurrency && !hasAmountOff {
+				return fmt.Errorf("currency: can only be set when amount_off is set")
+			}
+		}
 	}
+
+	if d.NewValueKnown("duration") && d.NewValueKnown("duration_in_months") {
+		_, hasMonths := d.GetOk("duration_in_months")
+		repeating := d.Get("duration").(string) == "repeating"
+		if hasMonths && !repeating {
+			return fmt.Errorf("duration_in_months: can only be set when duration is \"repeating\"")
+		}
+		if repeating && !hasMonths {
+			return fmt.Errorf("duration_in_months: required when duration is \"repeating\"")
+		}
+	}
+
+	return nil
 }
 
 func resourceStripeCouponCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*client.API)
+	client := m.(*providerMeta).API
 	couponID := d.Get("code").(string)
 
 	params := &stripe.CouponParams{
@@ -105,24 +145,12 @@ func resourceStripeCouponCreate(ctx context.Context, d *schema.ResourceData, m i
 	params.Context = ctx
 
 	couponDuration := d.Get("duration").(string)
-	validDurations := map[string]bool{
-		"repeating": true,
-		"once":      true,
-		"forever":   true,
-	}
-	if !(validDurations)[couponDuration] {
-		formattedKeys := "( " + strings.Join(getMapKeys(validDurations), " | ") + " )"
-		return diag.Errorf("\"%s\" is not a valid value for \"duration\", expected one of %s", couponDuration, formattedKeys)
-	}
 
 	if name, ok := d.GetOk("name"); ok {
 		params.Name = stripe.String(name.(string))
 	}
 
 	if durationInMonths, ok := d.GetOk("duration_in_months"); ok {
-		if couponDuration != "repeating" {
-			return diag.Errorf("can't set duration in months if event is not repeating")
-		}
 		params.DurationInMonths = stripe.Int64(int64(durationInMonths.(int)))
 	}
 
@@ -143,9 +171,6 @@ func resourceStripeCouponCreate(ctx context.Context, d *schema.ResourceData, m i
 	}
 
 	if currency, ok := d.GetOk("currency"); ok {
-		if params.AmountOff == nil {
-			return diag.Errorf("can only set currency when using amount_off")
-		}
 		params.Currency = stripe.String(currency.(string))
 	}
 
@@ -159,8 +184,13 @@ func resourceStripeCouponCreate(ctx context.Context, d *schema.ResourceData, m i
 		params.RedeemBy = stripe.Int64(redeemByTime.Unix())
 	}
 
-	params.Metadata = expandMetadata(d)
+	metadata, diags := expandMetadata(d)
+	if diags.HasError() {
+		return diags
+	}
+	params.Metadata = metadata
 
+	setIdempotencyKey(params, d)
 	coupon, err := client.Coupons.New(params)
 	if err != nil {
 		return diag.FromErr(err)
@@ -168,15 +198,24 @@ func resourceStripeCouponCreate(ctx context.Context, d *schema.ResourceData, m i
 
 	log.Printf("[INFO] Create coupon: %s (%s)", coupon.Name, coupon.ID)
 	d.SetId(coupon.ID)
-	d.Set("valid", coupon.Valid)
-	d.Set("created", coupon.Created)
-	d.Set("times_redeemed", coupon.TimesRedeemed)
-	d.Set("livemode", coupon.Livemode)
-	return nil
+
+	return resourceStripeCouponRead(ctx, d, m)
+}
+
+func resourceStripeCouponLivemode(ctx context.Context, client *client.API, id string) (bool, error) {
+	params := &stripe.CouponParams{}
+	params.Context = ctx
+
+	coupon, err := client.Coupons.Get(id, params)
+	if err != nil {
+		return false, err
+	}
+
+	return coupon.Livemode, nil
 }
 
 func resourceStripeCouponRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*client.API)
+	client := m.(*providerMeta).API
 
 	params := &stripe.CouponParams{}
 	params.Context = ctx
@@ -199,18 +238,22 @@ func resourceStripeCouponRead(ctx context.Context, d *schema.ResourceData, m int
 	d.Set("redeem_by", coupon.RedeemBy)
 	d.Set("times_redeemed", coupon.TimesRedeemed)
 	d.Set("valid", coupon.Valid)
-	d.Set("created", coupon.Valid)
+	d.Set("created", coupon.Created)
 	return nil
 }
 
 func resourceStripeCouponUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*client.API)
+	client := m.(*providerMeta).API
 
 	params := &stripe.CouponParams{}
 	params.Context = ctx
 
 	if d.HasChange("metadata") {
-		params.Metadata = expandMetadata(d)
+		metadata, diags := expandMetadata(d)
+		if diags.HasError() {
+			return diags
+		}
+		params.Metadata = metadata
 	}
 
 	if d.HasChange("name") {
@@ -225,7 +268,7 @@ func resourceStripeCouponUpdate(ctx context.Context, d *schema.ResourceData, m i
 }
 
 func resourceStripeCouponDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*client.API)
+	client := m.(*providerMeta).API
 
 	params := &stripe.CouponParams{}
 	params.Context = ctx
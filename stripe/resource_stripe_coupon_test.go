@@ -0,0 +1,192 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceStripeCouponReadCreated(t *testing.T) {
+	m := testProviderMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/coupons/SUMMER" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		fmt.Fprint(w, `{"id": "SUMMER", "object": "coupon", "duration": "once", "percent_off": 25, "valid": true, "created": 1609459200}`)
+	})
+
+	r := resourceStripeCoupon()
+	d := r.TestResourceData()
+	d.SetId("SUMMER")
+
+	if diags := r.ReadContext(context.Background(), d, m); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if got := d.Get("created").(int); got != 1609459200 {
+		t.Errorf("expected created 1609459200, got %d", got)
+	}
+	if got := d.Get("valid").(bool); !got {
+		t.Errorf("expected the coupon to be valid")
+	}
+}
+
+func TestResourceStripeCouponCreateReadsBack(t *testing.T) {
+	m := testProviderMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "SUMMER", "object": "coupon", "duration": "once", "percent_off": 25, "valid": true, "created": 1609459200, "livemode": true}`)
+	})
+
+	r := resourceStripeCoupon()
+	d := testResourceCreateData(t, r, map[string]interface{}{
+		"code":        "SUMMER",
+		"duration":    "once",
+		"percent_off": 25,
+	})
+
+	if diags := r.CreateContext(context.Background(), d, m); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if got := d.Get("created").(int); got != 1609459200 {
+		t.Errorf("expected created 1609459200, got %d", got)
+	}
+	if got := d.Get("livemode").(bool); !got {
+		t.Errorf("expected livemode to be read back")
+	}
+}
+
+func TestResourceStripeCouponRefreshRedemptions(t *testing.T) {
+	timesRedeemed, valid := 0, true
+	m := testProviderMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"id": "SUMMER", "object": "coupon", "duration": "once", "percent_off": 25, "name": "25%% off", "max_redemptions": 1, "times_redeemed": %d, "valid": %t}`, timesRedeemed, valid)
+	})
+
+	config := map[string]interface{}{
+		"code":            "SUMMER",
+		"duration":        "once",
+		"percent_off":     25,
+		"max_redemptions": 1,
+	}
+
+	r := resourceStripeCoupon()
+	d := r.TestResourceData()
+	d.SetId("SUMMER")
+
+	if diags := r.ReadContext(context.Background(), d, m); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	// The coupon gets redeemed outside of Terraform.
+	timesRedeemed, valid = 1, false
+
+	if diags := r.ReadContext(context.Background(), d, m); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if got := d.Get("times_redeemed").(int); got != 1 {
+		t.Errorf("expected times_redeemed 1, got %d", got)
+	}
+	if got := d.Get("valid").(bool); got {
+		t.Errorf("expected the fully redeemed coupon not to be valid anymore")
+	}
+
+	diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !diff.Empty() {
+		t.Errorf("expected no diff, got %v", diff.Attributes)
+	}
+}
+
+func TestResourceStripeCouponCustomizeDiffCurrency(t *testing.T) {
+	cases := []struct {
+		name   string
+		config map[string]interface{}
+		err    string
+	}{
+		{
+			name:   "amount_off with currency",
+			config: map[string]interface{}{"amount_off": 500, "currency": "usd"},
+		},
+		{
+			name:   "amount_off without currency",
+			config: map[string]interface{}{"amount_off": 500},
+			err:    "currency: required when amount_off is set",
+		},
+		{
+			name:   "percent_off with currency",
+			config: map[string]interface{}{"percent_off": 25, "currency": "usd"},
+			err:    "currency: can only be set when amount_off is set",
+		},
+		{
+			name:   "percent_off without currency",
+			config: map[string]interface{}{"percent_off": 25},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := map[string]interface{}{
+				"code":     "SUMMER",
+				"duration": "once",
+			}
+			for key, value := range tc.config {
+				config[key] = value
+			}
+
+			_, err := resourceStripeCoupon().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil)
+			if tc.err == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("expected error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestResourceStripeCouponGeneratedName(t *testing.T) {
+	m := testProviderMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			if err := r.ParseForm(); err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := r.PostForm["name"]; ok {
+				t.Errorf("expected no name to be sent, got %q", r.PostForm.Get("name"))
+			}
+		}
+		fmt.Fprint(w, `{"id": "SUMMER", "object": "coupon", "duration": "once", "percent_off": 25, "name": "25% off", "valid": true}`)
+	})
+
+	config := map[string]interface{}{
+		"code":        "SUMMER",
+		"duration":    "once",
+		"percent_off": 25,
+	}
+
+	r := resourceStripeCoupon()
+	d := testResourceCreateData(t, r, config)
+
+	if diags := r.CreateContext(context.Background(), d, m); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if got := d.Get("name"); got != "25% off" {
+		t.Errorf("expected the generated name to be read back, got %q", got)
+	}
+
+	diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !diff.Empty() {
+		t.Errorf("expected no diff, got %v", diff.Attributes)
+	}
+}
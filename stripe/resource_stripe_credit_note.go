@@ -0,0 +1,283 @@
+package stripe
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+// Credit notes can't change once issued except for their memo and metadata,
+// everything else recreates them. They can't be imported: their lines and
+// how they were split between credit and refund aren't read back, so an
+// imported credit note would be voided and replaced on the next apply.
+func resourceStripeCreditNote() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceStripeCreditNoteCreate,
+		ReadContext:   resourceStripeCreditNoteRead,
+		UpdateContext: resourceStripeCreditNoteUpdate,
+		DeleteContext: resourceStripeCreditNoteDelete,
+
+		Schema: map[string]*schema.Schema{
+			"invoice": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"amount": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"amount", "lines"},
+			},
+			// Stripe returns the lines it computed, so only the configured
+			// ones are kept.
+			"lines": {
+				Type: schema.TypeList,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateEnum("invoice_line_item", "custom_line_item"),
+						},
+						"invoice_line_item": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"amount": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"quantity": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"unit_amount": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+				Optional: true,
+				ForceNew: true,
+			},
+			"reason": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateEnum("duplicate", "fraudulent", "order_change", "product_unsatisfactory"),
+			},
+			// Amount credited to the customer balance.
+			"credit_amount": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+			},
+			// Amount refunded through the invoice's payment.
+			"refund_amount": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+			},
+			"memo": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"metadata": {
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional: true,
+			},
+			// Computed
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"number": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"pdf": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"total": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"created": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"livemode": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func expandCreditNoteLines(in []interface{}) []*stripe.CreditNoteLineParams {
+	out := make([]*stripe.CreditNoteLineParams, 0, len(in))
+	for _, v := range in {
+		line := v.(map[string]interface{})
+		params := &stripe.CreditNoteLineParams{
+			Type: stripe.String(line["type"].(string)),
+		}
+
+		if invoiceLineItem := line["invoice_line_item"].(string); invoiceLineItem != "" {
+			params.InvoiceLineItem = stripe.String(invoiceLineItem)
+		}
+		if amount := line["amount"].(int); amount > 0 {
+			params.Amount = stripe.Int64(int64(amount))
+		}
+		if quantity := line["quantity"].(int); quantity > 0 {
+			params.Quantity = stripe.Int64(int64(quantity))
+		}
+		if unitAmount := line["unit_amount"].(int); unitAmount > 0 {
+			params.UnitAmount = stripe.Int64(int64(unitAmount))
+		}
+		if description := line["description"].(string); description != "" {
+			params.Description = stripe.String(description)
+		}
+
+		out = append(out, params)
+	}
+
+	return out
+}
+
+func resourceStripeCreditNoteCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+	invoice := d.Get("invoice").(string)
+
+	params := &stripe.CreditNoteParams{
+		Invoice: stripe.String(invoice),
+	}
+	params.Context = ctx
+
+	if amount, ok := d.GetOk("amount"); ok {
+		params.Amount = stripe.Int64(int64(amount.(int)))
+	}
+
+	if lines, ok := d.GetOk("lines"); ok {
+		params.Lines = expandCreditNoteLines(lines.([]interface{}))
+	}
+
+	if reason, ok := d.GetOk("reason"); ok {
+		params.Reason = stripe.String(reason.(string))
+	}
+
+	if creditAmount, ok := d.GetOk("credit_amount"); ok {
+		params.CreditAmount = stripe.Int64(int64(creditAmount.(int)))
+	}
+
+	if refundAmount, ok := d.GetOk("refund_amount"); ok {
+		params.RefundAmount = stripe.Int64(int64(refundAmount.(int)))
+	}
+
+	if memo, ok := d.GetOk("memo"); ok {
+		params.Memo = stripe.String(memo.(string))
+	}
+
+	metadata, diags := expandMetadata(d)
+	if diags.HasError() {
+		return diags
+	}
+	params.Metadata = metadata
+
+	setIdempotencyKey(params, d)
+	creditNote, err := client.CreditNotes.New(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Create credit note: %s (%s)", creditNote.Number, creditNote.ID)
+	d.SetId(creditNote.ID)
+
+	return resourceStripeCreditNoteRead(ctx, d, m)
+}
+
+func resourceStripeCreditNoteRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.CreditNoteParams{}
+	params.Context = ctx
+
+	creditNote, err := client.CreditNotes.Get(d.Id(), params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if creditNote.Invoice != nil {
+		d.Set("invoice", creditNote.Invoice.ID)
+	}
+	d.Set("amount", creditNote.Amount)
+	d.Set("reason", creditNote.Reason)
+	d.Set("memo", creditNote.Memo)
+	d.Set("metadata", creditNote.Metadata)
+	d.Set("status", creditNote.Status)
+	d.Set("number", creditNote.Number)
+	d.Set("pdf", creditNote.PDF)
+	d.Set("total", creditNote.Total)
+	d.Set("created", creditNote.Created)
+	d.Set("livemode", creditNote.Livemode)
+
+	return nil
+}
+
+func resourceStripeCreditNoteUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.CreditNoteParams{}
+	params.Context = ctx
+
+	if d.HasChange("memo") {
+		params.Memo = stripe.String(d.Get("memo").(string))
+	}
+
+	if d.HasChange("metadata") {
+		metadata, diags := expandMetadata(d)
+		if diags.HasError() {
+			return diags
+		}
+		params.Metadata = metadata
+	}
+
+	if _, err := client.CreditNotes.Update(d.Id(), params); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStripeCreditNoteRead(ctx, d, m)
+}
+
+// resourceStripeCreditNoteDelete voids the credit note, which reverses its
+// effect on the invoice. Credit notes can't be deleted.
+func resourceStripeCreditNoteDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	if d.Get("status").(string) == string(stripe.CreditNoteStatusIssued) {
+		params := &stripe.CreditNoteVoidParams{}
+		params.Context = ctx
+
+		if _, err := client.CreditNotes.VoidCreditNote(d.Id(), params); err != nil {
+			return diag.FromErr(err)
+		}
+	} else {
+		log.Printf("[INFO] Credit note %s is %s, removing it from state only", d.Id(), d.Get("status").(string))
+	}
+
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,11 @@
+package stripe
+
+import "testing"
+
+func TestResourceStripeCreditNoteNotImportable(t *testing.T) {
+	// An imported credit note would be replaced, and so voided, on the next
+	// apply since its lines and credit/refund split aren't read back.
+	if Provider().ResourcesMap["stripe_credit_note"].Importer != nil {
+		t.Error("expected stripe_credit_note not to be importable")
+	}
+}
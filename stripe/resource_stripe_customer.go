@@ -0,0 +1,398 @@
+package stripe
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	stripe "github.com/stripe/stripe-go/v72"
+	"github.com/stripe/stripe-go/v72/client"
+)
+
+func resourceStripeCustomer() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceStripeCustomerCreate,
+		ReadContext:   resourceStripeCustomerRead,
+		UpdateContext: resourceStripeCustomerUpdate,
+		DeleteContext: resourceStripeCustomerDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateCheckingLivemode(resourceStripeCustomerLivemode),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"email": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"phone": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"address": addressSchema(),
+			"shipping": {
+				Type: schema.TypeList,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"phone": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"address": addressSchema(),
+					},
+				},
+				MaxItems: 1,
+				Optional: true,
+			},
+			"metadata": {
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional: true,
+			},
+			"balance": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"tax_exempt": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "none",
+				ValidateFunc: validateEnum("none", "exempt", "reverse"),
+			},
+			"preferred_locales": {
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Optional: true,
+			},
+			// Legacy sources and cards. Stripe picks the first one attached
+			// when it isn't set.
+			"default_source": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"invoice_settings": {
+				Type: schema.TypeList,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						// Payment method attached to the customer, used by
+						// subscriptions and invoices instead of default_source.
+						"default_payment_method": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+					},
+				},
+				MaxItems: 1,
+				Optional: true,
+				Computed: true,
+			},
+			"created": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"livemode": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+		CustomizeDiff: resourceStripeCustomerCustomizeDiff,
+	}
+}
+
+const customerPaymentDefaultsWarning = "default_source is ignored by invoices and subscriptions when invoice_settings.default_payment_method is set"
+
+// resourceStripeCustomerCustomizeDiff warns about customers configuring both
+// payment defaults. CustomizeDiff can't return warnings, so it's logged here
+// and reported again by Create and Update.
+func resourceStripeCustomerCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if setsBothPaymentDefaults(d.GetRawConfig()) {
+		log.Printf("[WARN] Customer %s: %s", d.Id(), customerPaymentDefaultsWarning)
+	}
+
+	return nil
+}
+
+// setsBothPaymentDefaults checks the configuration, as both attributes are
+// computed from what Stripe sets.
+func setsBothPaymentDefaults(config cty.Value) bool {
+	if config.IsNull() || !config.IsKnown() {
+		return false
+	}
+
+	defaultPaymentMethod := cty.NullVal(cty.String)
+	if settings := config.GetAttr("invoice_settings"); settings.IsKnown() && !settings.IsNull() && settings.LengthInt() > 0 {
+		defaultPaymentMethod = settings.Index(cty.NumberIntVal(0)).GetAttr("default_payment_method")
+	}
+
+	return isConfigured(config.GetAttr("default_source")) && isConfigured(defaultPaymentMethod)
+}
+
+func isConfigured(v cty.Value) bool {
+	return !v.IsNull() && (!v.IsKnown() || v.AsString() != "")
+}
+
+func customerPaymentDefaultsDiagnostics(d *schema.ResourceData) diag.Diagnostics {
+	if !setsBothPaymentDefaults(d.GetRawConfig()) {
+		return nil
+	}
+
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  "Customer sets both default_source and invoice_settings.default_payment_method",
+			Detail:   customerPaymentDefaultsWarning + ", consider removing default_source once payment methods are migrated.",
+		},
+	}
+}
+
+func expandCustomerShipping(in []interface{}) *stripe.CustomerShippingDetailsParams {
+	if len(in) == 0 || in[0] == nil {
+		return nil
+	}
+
+	shipping := in[0].(map[string]interface{})
+	return &stripe.CustomerShippingDetailsParams{
+		Name:    stripe.String(shipping["name"].(string)),
+		Phone:   stripe.String(shipping["phone"].(string)),
+		Address: expandAddress(shipping["address"].([]interface{})),
+	}
+}
+
+func flattenCustomerShipping(in *stripe.CustomerShippingDetails) []map[string]interface{} {
+	if in == nil {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{
+		{
+			"name":    in.Name,
+			"phone":   in.Phone,
+			"address": flattenAddress(in.Address),
+		},
+	}
+}
+
+func resourceStripeCustomerCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.CustomerParams{}
+	params.Context = ctx
+
+	if email, ok := d.GetOk("email"); ok {
+		params.Email = stripe.String(email.(string))
+	}
+
+	if name, ok := d.GetOk("name"); ok {
+		params.Name = stripe.String(name.(string))
+	}
+
+	if description, ok := d.GetOk("description"); ok {
+		params.Description = stripe.String(description.(string))
+	}
+
+	if phone, ok := d.GetOk("phone"); ok {
+		params.Phone = stripe.String(phone.(string))
+	}
+
+	params.Address = expandAddress(d.Get("address").([]interface{}))
+	params.Shipping = expandCustomerShipping(d.Get("shipping").([]interface{}))
+
+	metadata, diags := expandMetadata(d)
+	if diags.HasError() {
+		return diags
+	}
+	params.Metadata = metadata
+
+	if balance, ok := d.GetOk("balance"); ok {
+		params.Balance = stripe.Int64(int64(balance.(int)))
+	}
+
+	if taxExempt, ok := d.GetOk("tax_exempt"); ok {
+		params.TaxExempt = stripe.String(taxExempt.(string))
+	}
+
+	params.PreferredLocales = expandStringList(d, "preferred_locales")
+
+	if defaultSource, ok := d.GetOk("default_source"); ok {
+		params.DefaultSource = stripe.String(defaultSource.(string))
+	}
+
+	if defaultPaymentMethod, ok := d.GetOk("invoice_settings.0.default_payment_method"); ok {
+		params.InvoiceSettings = &stripe.CustomerInvoiceSettingsParams{
+			DefaultPaymentMethod: stripe.String(defaultPaymentMethod.(string)),
+		}
+	}
+
+	setIdempotencyKey(params, d)
+	customer, err := client.Customers.New(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Create customer: %s", customer.ID)
+	d.SetId(customer.ID)
+
+	return append(customerPaymentDefaultsDiagnostics(d), resourceStripeCustomerRead(ctx, d, m)...)
+}
+
+func resourceStripeCustomerLivemode(ctx context.Context, client *client.API, id string) (bool, error) {
+	params := &stripe.CustomerParams{}
+	params.Context = ctx
+
+	customer, err := client.Customers.Get(id, params)
+	if err != nil {
+		return false, err
+	}
+
+	return customer.Livemode, nil
+}
+
+func resourceStripeCustomerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.CustomerParams{}
+	params.Context = ctx
+
+	customer, err := client.Customers.Get(d.Id(), params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("email", customer.Email)
+	d.Set("name", customer.Name)
+	d.Set("description", customer.Description)
+	d.Set("phone", customer.Phone)
+	d.Set("address", flattenAddress(customer.Address))
+	d.Set("shipping", flattenCustomerShipping(customer.Shipping))
+	d.Set("metadata", customer.Metadata)
+	d.Set("balance", customer.Balance)
+	d.Set("tax_exempt", customer.TaxExempt)
+	d.Set("preferred_locales", customer.PreferredLocales)
+	if customer.DefaultSource != nil {
+		d.Set("default_source", customer.DefaultSource.ID)
+	} else {
+		d.Set("default_source", "")
+	}
+	defaultPaymentMethod := ""
+	if customer.InvoiceSettings != nil && customer.InvoiceSettings.DefaultPaymentMethod != nil {
+		defaultPaymentMethod = customer.InvoiceSettings.DefaultPaymentMethod.ID
+	}
+	d.Set("invoice_settings", []map[string]interface{}{
+		{
+			"default_payment_method": defaultPaymentMethod,
+		},
+	})
+	d.Set("created", customer.Created)
+	d.Set("livemode", customer.Livemode)
+
+	return nil
+}
+
+func resourceStripeCustomerUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.CustomerParams{}
+	params.Context = ctx
+
+	if d.HasChange("email") {
+		params.Email = stripe.String(d.Get("email").(string))
+	}
+
+	if d.HasChange("name") {
+		params.Name = stripe.String(d.Get("name").(string))
+	}
+
+	if d.HasChange("description") {
+		params.Description = stripe.String(d.Get("description").(string))
+	}
+
+	if d.HasChange("phone") {
+		params.Phone = stripe.String(d.Get("phone").(string))
+	}
+
+	if d.HasChange("address") {
+		params.Address = expandAddress(d.Get("address").([]interface{}))
+		if params.Address == nil {
+			params.AddExtra("address", "")
+		}
+	}
+
+	if d.HasChange("shipping") {
+		params.Shipping = expandCustomerShipping(d.Get("shipping").([]interface{}))
+		if params.Shipping == nil {
+			params.AddExtra("shipping", "")
+		}
+	}
+
+	if d.HasChange("metadata") {
+		metadata, diags := expandMetadata(d)
+		if diags.HasError() {
+			return diags
+		}
+		params.Metadata = metadata
+	}
+
+	if d.HasChange("balance") {
+		params.Balance = stripe.Int64(int64(d.Get("balance").(int)))
+	}
+
+	if d.HasChange("tax_exempt") {
+		params.TaxExempt = stripe.String(d.Get("tax_exempt").(string))
+	}
+
+	if d.HasChange("preferred_locales") {
+		params.PreferredLocales = expandStringList(d, "preferred_locales")
+		if params.PreferredLocales == nil {
+			params.AddExtra("preferred_locales", "")
+		}
+	}
+
+	if d.HasChange("default_source") {
+		params.DefaultSource = stripe.String(d.Get("default_source").(string))
+	}
+
+	if d.HasChange("invoice_settings.0.default_payment_method") {
+		params.InvoiceSettings = &stripe.CustomerInvoiceSettingsParams{
+			DefaultPaymentMethod: stripe.String(d.Get("invoice_settings.0.default_payment_method").(string)),
+		}
+	}
+
+	if _, err := client.Customers.Update(d.Id(), params); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return append(customerPaymentDefaultsDiagnostics(d), resourceStripeCustomerRead(ctx, d, m)...)
+}
+
+func resourceStripeCustomerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.CustomerParams{}
+	params.Context = ctx
+
+	if _, err := client.Customers.Del(d.Id(), params); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,237 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	stripe "github.com/stripe/stripe-go/v72"
+	"github.com/stripe/stripe-go/v72/client"
+)
+
+func resourceStripeCustomerBalanceTransaction() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceStripeCustomerBalanceTransactionCreate,
+		ReadContext:   resourceStripeCustomerBalanceTransactionRead,
+		UpdateContext: resourceStripeCustomerBalanceTransactionUpdate,
+		DeleteContext: resourceStripeCustomerBalanceTransactionDelete,
+		CustomizeDiff: resourceStripeCustomerBalanceTransactionCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateChildOf("customer", resourceStripeCustomerBalanceTransactionLivemode),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"customer": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			// Negative amounts credit the customer, positive ones debit it.
+			"amount": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"currency": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"metadata": {
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional: true,
+			},
+			"reverse_on_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			// Computed
+			"ending_balance": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"created": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"livemode": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// resourceStripeCustomerBalanceTransactionCustomizeDiff rejects replacing a
+// transaction that won't be reversed, the new one would add up with it in the
+// balance of the customer. Deleting it uses the reverse_on_destroy of the
+// state, so it has to be applied before the replacement.
+func resourceStripeCustomerBalanceTransactionCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	reverse, _ := d.GetChange("reverse_on_destroy")
+	if reverse.(bool) {
+		return nil
+	}
+
+	for _, key := range []string{"customer", "amount", "currency"} {
+		if d.HasChange(key) {
+			return fmt.Errorf("%s: changing it replaces the transaction, which stays in the balance of the customer unless reverse_on_destroy has been applied first", key)
+		}
+	}
+
+	return nil
+}
+
+func resourceStripeCustomerBalanceTransactionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+	customer := d.Get("customer").(string)
+
+	params := &stripe.CustomerBalanceTransactionParams{
+		Customer: stripe.String(customer),
+		Amount:   stripe.Int64(int64(d.Get("amount").(int))),
+		Currency: stripe.String(d.Get("currency").(string)),
+	}
+	params.Context = ctx
+
+	if description, ok := d.GetOk("description"); ok {
+		params.Description = stripe.String(description.(string))
+	}
+
+	metadata, diags := expandMetadata(d)
+	if diags.HasError() {
+		return diags
+	}
+	params.Metadata = metadata
+
+	// Never sent with an idempotency key, so that no adjustment can be
+	// mistaken for a previous one and not move money.
+	transaction, err := client.CustomerBalanceTransactions.New(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Create customer balance transaction: %s (%s)", transaction.ID, customer)
+	d.SetId(transaction.ID)
+
+	return resourceStripeCustomerBalanceTransactionRead(ctx, d, m)
+}
+
+func resourceStripeCustomerBalanceTransactionLivemode(ctx context.Context, client *client.API, customer, id string) (bool, error) {
+	params := &stripe.CustomerBalanceTransactionParams{
+		Customer: stripe.String(customer),
+	}
+	params.Context = ctx
+
+	transaction, err := client.CustomerBalanceTransactions.Get(id, params)
+	if err != nil {
+		return false, err
+	}
+
+	return transaction.Livemode, nil
+}
+
+func resourceStripeCustomerBalanceTransactionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.CustomerBalanceTransactionParams{
+		Customer: stripe.String(d.Get("customer").(string)),
+	}
+	params.Context = ctx
+
+	transaction, err := client.CustomerBalanceTransactions.Get(d.Id(), params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("amount", transaction.Amount)
+	d.Set("currency", transaction.Currency)
+	d.Set("description", transaction.Description)
+	d.Set("metadata", transaction.Metadata)
+	d.Set("ending_balance", transaction.EndingBalance)
+	d.Set("type", transaction.Type)
+	d.Set("created", transaction.Created)
+	d.Set("livemode", transaction.Livemode)
+
+	return nil
+}
+
+func resourceStripeCustomerBalanceTransactionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.CustomerBalanceTransactionParams{
+		Customer: stripe.String(d.Get("customer").(string)),
+	}
+	params.Context = ctx
+
+	if d.HasChange("description") {
+		params.Description = stripe.String(d.Get("description").(string))
+	}
+
+	if d.HasChange("metadata") {
+		metadata, diags := expandMetadata(d)
+		if diags.HasError() {
+			return diags
+		}
+		params.Metadata = metadata
+	}
+
+	if _, err := client.CustomerBalanceTransactions.Update(d.Id(), params); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStripeCustomerBalanceTransactionRead(ctx, d, m)
+}
+
+func resourceStripeCustomerBalanceTransactionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+	id := d.Id()
+	customer := d.Get("customer").(string)
+
+	// Stripe doesn't allow deleting balance transactions, they can only be
+	// offset by an opposite one.
+	if !d.Get("reverse_on_destroy").(bool) {
+		d.SetId("")
+		return diag.Diagnostics{
+			{
+				Severity: diag.Warning,
+				Summary:  "Customer balance transaction left in place",
+				Detail:   fmt.Sprintf("Stripe doesn't allow deleting customer balance transactions, %s has only been removed from the state and still counts in the balance of %s. Set reverse_on_destroy to offset it instead.", id, customer),
+			},
+		}
+	}
+
+	params := &stripe.CustomerBalanceTransactionParams{
+		Customer:    stripe.String(customer),
+		Amount:      stripe.Int64(-int64(d.Get("amount").(int))),
+		Currency:    stripe.String(d.Get("currency").(string)),
+		Description: stripe.String(fmt.Sprintf("Reversal of %s", id)),
+	}
+	params.Context = ctx
+
+	reversal, err := client.CustomerBalanceTransactions.New(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Reversed customer balance transaction %s with %s", id, reversal.ID)
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package stripe
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceStripeCustomerBalanceTransactionCustomizeDiff(t *testing.T) {
+	cases := []struct {
+		name    string
+		reverse bool
+		config  map[string]interface{}
+		err     string
+	}{
+		{
+			name:   "description changed",
+			config: map[string]interface{}{"description": "Goodwill"},
+		},
+		{
+			name:   "amount changed",
+			config: map[string]interface{}{"amount": -2000},
+			err:    "amount: changing it replaces the transaction",
+		},
+		{
+			name:   "currency changed",
+			config: map[string]interface{}{"currency": "eur"},
+			err:    "currency: changing it replaces the transaction",
+		},
+		{
+			name:   "amount changed while enabling the reversal",
+			config: map[string]interface{}{"amount": -2000, "reverse_on_destroy": true},
+			err:    "amount: changing it replaces the transaction",
+		},
+		{
+			name:    "amount changed with the reversal applied",
+			reverse: true,
+			config:  map[string]interface{}{"amount": -2000, "reverse_on_destroy": true},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := resourceStripeCustomerBalanceTransaction()
+			d := r.TestResourceData()
+			d.SetId("cbtxn_123")
+			d.Set("customer", "cus_123")
+			d.Set("amount", -1000)
+			d.Set("currency", "usd")
+			d.Set("reverse_on_destroy", tc.reverse)
+
+			config := map[string]interface{}{
+				"customer":           "cus_123",
+				"amount":             -1000,
+				"currency":           "usd",
+				"reverse_on_destroy": tc.reverse,
+			}
+			for key, value := range tc.config {
+				config[key] = value
+			}
+
+			diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), nil)
+			if tc.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if diff == nil || diff.Empty() {
+					t.Fatal("expected a diff")
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("expected error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package stripe
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	stripe "github.com/stripe/stripe-go/v72"
+	"github.com/stripe/stripe-go/v72/client"
+)
+
+// Stripe doesn't allow updating tax IDs, every change recreates them.
+func resourceStripeCustomerTaxID() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceStripeCustomerTaxIDCreate,
+		ReadContext:   resourceStripeCustomerTaxIDRead,
+		DeleteContext: resourceStripeCustomerTaxIDDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateChildOf("customer", resourceStripeCustomerTaxIDLivemode),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"customer": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"value": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			// Computed
+			"country": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"verification_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"created": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"livemode": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceStripeCustomerTaxIDCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+	customer := d.Get("customer").(string)
+
+	params := &stripe.TaxIDParams{
+		Customer: stripe.String(customer),
+		Type:     stripe.String(d.Get("type").(string)),
+		Value:    stripe.String(d.Get("value").(string)),
+	}
+	params.Context = ctx
+
+	setIdempotencyKey(params, d)
+	taxID, err := client.TaxIDs.New(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Create tax ID: %s (%s)", taxID.ID, customer)
+	d.SetId(taxID.ID)
+
+	return resourceStripeCustomerTaxIDRead(ctx, d, m)
+}
+
+func resourceStripeCustomerTaxIDLivemode(ctx context.Context, client *client.API, customer, id string) (bool, error) {
+	params := &stripe.TaxIDParams{
+		Customer: stripe.String(customer),
+	}
+	params.Context = ctx
+
+	taxID, err := client.TaxIDs.Get(id, params)
+	if err != nil {
+		return false, err
+	}
+
+	return taxID.Livemode, nil
+}
+
+func resourceStripeCustomerTaxIDRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.TaxIDParams{
+		Customer: stripe.String(d.Get("customer").(string)),
+	}
+	params.Context = ctx
+
+	taxID, err := client.TaxIDs.Get(d.Id(), params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("type", taxID.Type)
+	d.Set("value", taxID.Value)
+	d.Set("country", taxID.Country)
+	if taxID.Verification != nil {
+		d.Set("verification_status", taxID.Verification.Status)
+	}
+	d.Set("created", taxID.Created)
+	d.Set("livemode", taxID.Livemode)
+
+	return nil
+}
+
+func resourceStripeCustomerTaxIDDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.TaxIDParams{
+		Customer: stripe.String(d.Get("customer").(string)),
+	}
+	params.Context = ctx
+
+	if _, err := client.TaxIDs.Del(d.Id(), params); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,162 @@
+package stripe
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+// Stripe doesn't allow updating nor deleting files, every change uploads a new
+// one and destroying them only removes them from the state.
+func resourceStripeFile() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceStripeFileCreate,
+		ReadContext:   resourceStripeFileRead,
+		DeleteContext: resourceStripeFileDelete,
+
+		Schema: map[string]*schema.Schema{
+			// Path of a local file to upload.
+			"source": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"source", "content_base64"},
+			},
+			// Content to upload, e.g. from filebase64().
+			"content_base64": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			// Defaults to the name of source.
+			"filename": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"content_base64"},
+			},
+			"purpose": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validateEnum(
+					"account_requirement",
+					"additional_verification",
+					"business_icon",
+					"business_logo",
+					"customer_signature",
+					"dispute_evidence",
+					"identity_document",
+					"pci_document",
+					"tax_document_user_upload",
+					"terminal_reader_splashscreen",
+				),
+			},
+			// Computed
+			"url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"size": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"created": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceStripeFileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	var content io.Reader
+	filename := d.Get("filename").(string)
+	if source, ok := d.GetOk("source"); ok {
+		f, err := os.Open(source.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		defer f.Close()
+
+		content = f
+		if filename == "" {
+			filename = filepath.Base(source.(string))
+		}
+	} else {
+		decoded, err := base64.StdEncoding.DecodeString(d.Get("content_base64").(string))
+		if err != nil {
+			return diag.Errorf("content_base64 isn't valid base64: %s", err)
+		}
+		content = bytes.NewReader(decoded)
+	}
+
+	params := &stripe.FileParams{
+		FileReader: content,
+		Filename:   stripe.String(filename),
+		Purpose:    stripe.String(d.Get("purpose").(string)),
+	}
+	params.Context = ctx
+
+	// Uploads aren't sent with an idempotency key derived from their
+	// parameters, as those leave the content out.
+	file, err := client.Files.New(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Create file: %s (%s)", file.ID, filename)
+	d.SetId(file.ID)
+
+	return resourceStripeFileRead(ctx, d, m)
+}
+
+func resourceStripeFileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.FileParams{}
+	params.Context = ctx
+
+	file, err := client.Files.Get(d.Id(), params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("filename", file.Filename)
+	d.Set("purpose", file.Purpose)
+	d.Set("url", file.URL)
+	d.Set("type", file.Type)
+	d.Set("size", file.Size)
+	d.Set("created", file.Created)
+
+	return nil
+}
+
+func resourceStripeFileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id := d.Id()
+	d.SetId("")
+
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  "File left in place",
+			Detail:   fmt.Sprintf("Stripe doesn't allow deleting files, %s has only been removed from the state.", id),
+		},
+	}
+}
@@ -0,0 +1,193 @@
+package stripe
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	stripe "github.com/stripe/stripe-go/v72"
+	"github.com/stripe/stripe-go/v72/client"
+)
+
+// Stripe doesn't allow deleting file links, they're expired instead.
+func resourceStripeFileLink() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceStripeFileLinkCreate,
+		ReadContext:   resourceStripeFileLinkRead,
+		UpdateContext: resourceStripeFileLinkUpdate,
+		DeleteContext: resourceStripeFileLinkDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateCheckingLivemode(resourceStripeFileLinkLivemode),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"file": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"expires_at": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validation.IsRFC3339Time,
+				DiffSuppressFunc: suppressEquivalentTime,
+			},
+			"metadata": {
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional: true,
+			},
+			// Computed
+			"url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"expired": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"created": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"livemode": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceStripeFileLinkCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+	file := d.Get("file").(string)
+
+	params := &stripe.FileLinkParams{
+		File: stripe.String(file),
+	}
+	params.Context = ctx
+
+	if expiresAt, ok := d.GetOk("expires_at"); ok {
+		expiresAtTime, err := time.Parse(time.RFC3339, expiresAt.(string))
+		if err != nil {
+			return diag.Errorf("can't convert time \"%s\" to time.  Please check if it's RFC3339-compliant", expiresAt)
+		}
+
+		params.ExpiresAt = stripe.Int64(expiresAtTime.Unix())
+	}
+
+	metadata, diags := expandMetadata(d)
+	if diags.HasError() {
+		return diags
+	}
+	params.Metadata = metadata
+
+	setIdempotencyKey(params, d)
+	fileLink, err := client.FileLinks.New(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Create file link: %s (%s)", fileLink.ID, file)
+	d.SetId(fileLink.ID)
+
+	return resourceStripeFileLinkRead(ctx, d, m)
+}
+
+func resourceStripeFileLinkLivemode(ctx context.Context, client *client.API, id string) (bool, error) {
+	params := &stripe.FileLinkParams{}
+	params.Context = ctx
+
+	fileLink, err := client.FileLinks.Get(id, params)
+	if err != nil {
+		return false, err
+	}
+
+	return fileLink.Livemode, nil
+}
+
+func resourceStripeFileLinkRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.FileLinkParams{}
+	params.Context = ctx
+
+	fileLink, err := client.FileLinks.Get(d.Id(), params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if fileLink.File != nil {
+		d.Set("file", fileLink.File.ID)
+	}
+	if fileLink.ExpiresAt != 0 {
+		d.Set("expires_at", time.Unix(fileLink.ExpiresAt, 0).UTC().Format(time.RFC3339))
+	} else {
+		d.Set("expires_at", "")
+	}
+	d.Set("metadata", fileLink.Metadata)
+	d.Set("url", fileLink.URL)
+	d.Set("expired", fileLink.Expired)
+	d.Set("created", fileLink.Created)
+	d.Set("livemode", fileLink.Livemode)
+
+	return nil
+}
+
+func resourceStripeFileLinkUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.FileLinkParams{}
+	params.Context = ctx
+
+	if d.HasChange("expires_at") {
+		if expiresAt, ok := d.GetOk("expires_at"); ok {
+			expiresAtTime, err := time.Parse(time.RFC3339, expiresAt.(string))
+			if err != nil {
+				return diag.Errorf("can't convert time \"%s\" to time.  Please check if it's RFC3339-compliant", expiresAt)
+			}
+
+			params.ExpiresAt = stripe.Int64(expiresAtTime.Unix())
+		} else {
+			params.AddExtra("expires_at", "")
+		}
+	}
+
+	if d.HasChange("metadata") {
+		metadata, diags := expandMetadata(d)
+		if diags.HasError() {
+			return diags
+		}
+		params.Metadata = metadata
+	}
+
+	if _, err := client.FileLinks.Update(d.Id(), params); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStripeFileLinkRead(ctx, d, m)
+}
+
+func resourceStripeFileLinkDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	if !d.Get("expired").(bool) {
+		params := &stripe.FileLinkParams{
+			ExpiresAtNow: stripe.Bool(true),
+		}
+		params.Context = ctx
+
+		if _, err := client.FileLinks.Update(d.Id(), params); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,363 @@
+package stripe
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	stripe "github.com/stripe/stripe-go/v72"
+	"github.com/stripe/stripe-go/v72/client"
+)
+
+func resourceStripeInvoice() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceStripeInvoiceCreate,
+		ReadContext:   resourceStripeInvoiceRead,
+		UpdateContext: resourceStripeInvoiceUpdate,
+		DeleteContext: resourceStripeInvoiceDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateCheckingLivemode(resourceStripeInvoiceLivemode),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"customer": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"collection_method": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateEnum("charge_automatically", "send_invoice"),
+			},
+			"auto_advance": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			// Stripe only returns the resulting due date.
+			"days_until_due": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"default_tax_rates": {
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Optional: true,
+			},
+			// Connect: the platform's cut of the invoice. Unlike subscriptions,
+			// invoices only take a fixed amount.
+			"application_fee_amount": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"transfer_data": {
+				Type: schema.TypeList,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"destination": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateAccountID,
+						},
+						// Amount transferred, the whole invoice when omitted.
+						"amount": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+					},
+				},
+				MaxItems: 1,
+				Optional: true,
+			},
+			"metadata": {
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional: true,
+			},
+			// Finalized invoices can't go back to draft, and auto_advance may
+			// finalize them on its own, so it only matters for drafts.
+			"finalize": {
+				Type:             schema.TypeBool,
+				Optional:         true,
+				Default:          false,
+				DiffSuppressFunc: suppressFinalizeUnlessDraft,
+			},
+			// Computed
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"number": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"hosted_invoice_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"invoice_pdf": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"total": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"livemode": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func expandInvoiceTransferData(d *schema.ResourceData) *stripe.InvoiceTransferDataParams {
+	params := &stripe.InvoiceTransferDataParams{
+		Destination: stripe.String(d.Get("transfer_data.0.destination").(string)),
+	}
+
+	if amount, ok := d.GetOk("transfer_data.0.amount"); ok {
+		params.Amount = stripe.Int64(int64(amount.(int)))
+	}
+
+	return params
+}
+
+func resourceStripeInvoiceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+	customer := d.Get("customer").(string)
+
+	params := &stripe.InvoiceParams{
+		Customer: stripe.String(customer),
+	}
+	params.Context = ctx
+
+	if collectionMethod, ok := d.GetOk("collection_method"); ok {
+		params.CollectionMethod = stripe.String(collectionMethod.(string))
+	}
+
+	if autoAdvance, ok := d.GetOkExists("auto_advance"); ok {
+		params.AutoAdvance = stripe.Bool(autoAdvance.(bool))
+	}
+
+	if daysUntilDue, ok := d.GetOk("days_until_due"); ok {
+		params.DaysUntilDue = stripe.Int64(int64(daysUntilDue.(int)))
+	}
+
+	if description, ok := d.GetOk("description"); ok {
+		params.Description = stripe.String(description.(string))
+	}
+
+	params.DefaultTaxRates = expandStringList(d, "default_tax_rates")
+
+	if applicationFeeAmount, ok := d.GetOk("application_fee_amount"); ok {
+		params.ApplicationFeeAmount = stripe.Int64(int64(applicationFeeAmount.(int)))
+	}
+
+	if _, ok := d.GetOk("transfer_data"); ok {
+		params.TransferData = expandInvoiceTransferData(d)
+	}
+
+	metadata, diags := expandMetadata(d)
+	if diags.HasError() {
+		return diags
+	}
+	params.Metadata = metadata
+
+	setIdempotencyKey(params, d)
+	invoice, err := client.Invoices.New(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Create invoice: %s (%s)", invoice.ID, customer)
+	d.SetId(invoice.ID)
+
+	if d.Get("finalize").(bool) {
+		if err := finalizeInvoice(ctx, client, invoice.ID); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceStripeInvoiceRead(ctx, d, m)
+}
+
+func finalizeInvoice(ctx context.Context, client *client.API, id string) error {
+	params := &stripe.InvoiceFinalizeParams{}
+	params.Context = ctx
+
+	invoice, err := client.Invoices.FinalizeInvoice(id, params)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Finalized invoice: %s (%s)", invoice.ID, invoice.Number)
+	return nil
+}
+
+func resourceStripeInvoiceLivemode(ctx context.Context, client *client.API, id string) (bool, error) {
+	params := &stripe.InvoiceParams{}
+	params.Context = ctx
+
+	invoice, err := client.Invoices.Get(id, params)
+	if err != nil {
+		return false, err
+	}
+
+	return invoice.Livemode, nil
+}
+
+func resourceStripeInvoiceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.InvoiceParams{}
+	params.Context = ctx
+
+	invoice, err := client.Invoices.Get(d.Id(), params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if invoice.Customer != nil {
+		d.Set("customer", invoice.Customer.ID)
+	}
+	d.Set("collection_method", invoice.CollectionMethod)
+	d.Set("auto_advance", invoice.AutoAdvance)
+	d.Set("description", invoice.Description)
+	defaultTaxRates := make([]string, len(invoice.DefaultTaxRates))
+	for i, taxRate := range invoice.DefaultTaxRates {
+		defaultTaxRates[i] = taxRate.ID
+	}
+	d.Set("default_tax_rates", defaultTaxRates)
+	d.Set("application_fee_amount", invoice.ApplicationFeeAmount)
+	transferData := []map[string]interface{}{}
+	if invoice.TransferData != nil && invoice.TransferData.Destination != nil {
+		transferData = append(transferData, map[string]interface{}{
+			"destination": invoice.TransferData.Destination.ID,
+			"amount":      invoice.TransferData.Amount,
+		})
+	}
+	d.Set("transfer_data", transferData)
+	d.Set("metadata", invoice.Metadata)
+	d.Set("status", invoice.Status)
+	d.Set("number", invoice.Number)
+	d.Set("hosted_invoice_url", invoice.HostedInvoiceURL)
+	d.Set("invoice_pdf", invoice.InvoicePDF)
+	d.Set("total", invoice.Total)
+	d.Set("livemode", invoice.Livemode)
+
+	return nil
+}
+
+func resourceStripeInvoiceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.InvoiceParams{}
+	params.Context = ctx
+
+	if d.HasChange("collection_method") {
+		params.CollectionMethod = stripe.String(d.Get("collection_method").(string))
+	}
+
+	if d.HasChange("auto_advance") {
+		params.AutoAdvance = stripe.Bool(d.Get("auto_advance").(bool))
+	}
+
+	if d.HasChange("days_until_due") {
+		params.DaysUntilDue = stripe.Int64(int64(d.Get("days_until_due").(int)))
+	}
+
+	if d.HasChange("description") {
+		params.Description = stripe.String(d.Get("description").(string))
+	}
+
+	if d.HasChange("default_tax_rates") {
+		if defaultTaxRates := expandStringList(d, "default_tax_rates"); defaultTaxRates != nil {
+			params.DefaultTaxRates = defaultTaxRates
+		} else {
+			params.AddExtra("default_tax_rates", "")
+		}
+	}
+
+	if d.HasChange("application_fee_amount") {
+		if applicationFeeAmount, ok := d.GetOk("application_fee_amount"); ok {
+			params.ApplicationFeeAmount = stripe.Int64(int64(applicationFeeAmount.(int)))
+		} else {
+			params.AddExtra("application_fee_amount", "")
+		}
+	}
+
+	if d.HasChange("transfer_data") {
+		if _, ok := d.GetOk("transfer_data"); ok {
+			params.TransferData = expandInvoiceTransferData(d)
+		} else {
+			params.AddExtra("transfer_data", "")
+		}
+	}
+
+	if d.HasChange("metadata") {
+		metadata, diags := expandMetadata(d)
+		if diags.HasError() {
+			return diags
+		}
+		params.Metadata = metadata
+	}
+
+	if _, err := client.Invoices.Update(d.Id(), params); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChange("finalize") && d.Get("finalize").(bool) && d.Get("status").(string) == string(stripe.InvoiceStatusDraft) {
+		if err := finalizeInvoice(ctx, client, d.Id()); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceStripeInvoiceRead(ctx, d, m)
+}
+
+func resourceStripeInvoiceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.InvoiceParams{}
+	params.Context = ctx
+
+	invoice, err := client.Invoices.Get(d.Id(), params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Only drafts can be deleted, finalized invoices have to be voided and
+	// paid or voided ones can't change anymore.
+	switch invoice.Status {
+	case stripe.InvoiceStatusDraft:
+		if _, err := client.Invoices.Del(d.Id(), params); err != nil {
+			return diag.FromErr(err)
+		}
+	case stripe.InvoiceStatusOpen, stripe.InvoiceStatusUncollectible:
+		voidParams := &stripe.InvoiceVoidParams{}
+		voidParams.Context = ctx
+
+		if _, err := client.Invoices.VoidInvoice(d.Id(), voidParams); err != nil {
+			return diag.FromErr(err)
+		}
+	default:
+		log.Printf("[INFO] Invoice %s is %s, removing it from state only", d.Id(), invoice.Status)
+	}
+
+	d.SetId("")
+
+	return nil
+}
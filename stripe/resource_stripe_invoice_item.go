@@ -0,0 +1,374 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	stripe "github.com/stripe/stripe-go/v72"
+	"github.com/stripe/stripe-go/v72/client"
+)
+
+func resourceStripeInvoiceItem() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceStripeInvoiceItemCreate,
+		ReadContext:   resourceStripeInvoiceItemRead,
+		UpdateContext: resourceStripeInvoiceItemUpdate,
+		DeleteContext: resourceStripeInvoiceItemDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateCheckingLivemode(resourceStripeInvoiceItemLivemode),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"customer": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"price": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"price", "amount"},
+			},
+			"amount": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"price", "amount"},
+				RequiredWith: []string{"currency"},
+			},
+			"currency": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"quantity": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"invoice": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"period": {
+				Type: schema.TypeList,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"start": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+						"end": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+					},
+				},
+				MaxItems: 1,
+				Optional: true,
+				Computed: true,
+			},
+			"discounts": {
+				Type: schema.TypeList,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"coupon": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"promotion_code": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringMatch(regexp.MustCompile(`^promo_`), "must be a promotion code ID (promo_...)"),
+						},
+					},
+				},
+				Optional: true,
+			},
+			"tax_rates": {
+				Type: schema.TypeList,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringMatch(regexp.MustCompile(`^txr_`), "must be a tax rate ID (txr_...)"),
+				},
+				Optional: true,
+			},
+			"metadata": {
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional: true,
+			},
+			// Computed
+			"date": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"livemode": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// addInvoiceItemDiscounts sets the discounts as extra params, stripe-go
+// doesn't know about promotion codes on invoice items.
+func addInvoiceItemDiscounts(params *stripe.InvoiceItemParams, in []interface{}) error {
+	if len(in) == 0 {
+		params.AddExtra("discounts", "")
+		return nil
+	}
+
+	for i, v := range in {
+		discount, _ := v.(map[string]interface{})
+		coupon, _ := discount["coupon"].(string)
+		promotionCode, _ := discount["promotion_code"].(string)
+
+		switch {
+		case coupon != "" && promotionCode == "":
+			params.AddExtra(fmt.Sprintf("discounts[%d][coupon]", i), coupon)
+		case promotionCode != "" && coupon == "":
+			params.AddExtra(fmt.Sprintf("discounts[%d][promotion_code]", i), promotionCode)
+		default:
+			return fmt.Errorf("discounts.%d: exactly one of coupon or promotion_code must be set", i)
+		}
+	}
+
+	return nil
+}
+
+func flattenInvoiceItemDiscounts(in []*stripe.Discount) []map[string]interface{} {
+	out := make([]map[string]interface{}, 0, len(in))
+	for _, discount := range in {
+		if discount.PromotionCode != nil {
+			out = append(out, map[string]interface{}{
+				"promotion_code": discount.PromotionCode.ID,
+			})
+		} else if discount.Coupon != nil {
+			out = append(out, map[string]interface{}{
+				"coupon": discount.Coupon.ID,
+			})
+		}
+	}
+	return out
+}
+
+func expandInvoiceItemPeriod(in []interface{}) *stripe.InvoiceItemPeriodParams {
+	if len(in) == 0 || in[0] == nil {
+		return nil
+	}
+
+	period := in[0].(map[string]interface{})
+	return &stripe.InvoiceItemPeriodParams{
+		Start: stripe.Int64(int64(period["start"].(int))),
+		End:   stripe.Int64(int64(period["end"].(int))),
+	}
+}
+
+func resourceStripeInvoiceItemCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+	customer := d.Get("customer").(string)
+
+	params := &stripe.InvoiceItemParams{
+		Customer: stripe.String(customer),
+	}
+	params.Context = ctx
+
+	if price, ok := d.GetOk("price"); ok {
+		params.Price = stripe.String(price.(string))
+	}
+
+	// Negative amounts are credits, zero is a valid amount as well.
+	if amount, ok := d.GetOkExists("amount"); ok {
+		params.Amount = stripe.Int64(int64(amount.(int)))
+	}
+
+	if currency, ok := d.GetOk("currency"); ok {
+		params.Currency = stripe.String(currency.(string))
+	}
+
+	if quantity, ok := d.GetOk("quantity"); ok {
+		params.Quantity = stripe.Int64(int64(quantity.(int)))
+	}
+
+	if description, ok := d.GetOk("description"); ok {
+		params.Description = stripe.String(description.(string))
+	}
+
+	if invoice, ok := d.GetOk("invoice"); ok {
+		params.Invoice = stripe.String(invoice.(string))
+	}
+
+	if period, ok := d.GetOk("period"); ok {
+		params.Period = expandInvoiceItemPeriod(period.([]interface{}))
+	}
+
+	if discounts, ok := d.GetOk("discounts"); ok {
+		if err := addInvoiceItemDiscounts(params, discounts.([]interface{})); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	params.TaxRates = expandStringList(d, "tax_rates")
+
+	metadata, diags := expandMetadata(d)
+	if diags.HasError() {
+		return diags
+	}
+	params.Metadata = metadata
+
+	setIdempotencyKey(params, d)
+	invoiceItem, err := client.InvoiceItems.New(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Create invoice item: %s (%s)", invoiceItem.ID, customer)
+	d.SetId(invoiceItem.ID)
+
+	return resourceStripeInvoiceItemRead(ctx, d, m)
+}
+
+func resourceStripeInvoiceItemLivemode(ctx context.Context, client *client.API, id string) (bool, error) {
+	params := &stripe.InvoiceItemParams{}
+	params.Context = ctx
+
+	invoiceItem, err := client.InvoiceItems.Get(id, params)
+	if err != nil {
+		return false, err
+	}
+
+	return invoiceItem.Livemode, nil
+}
+
+func resourceStripeInvoiceItemRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.InvoiceItemParams{}
+	params.Context = ctx
+	params.AddExpand("discounts")
+
+	invoiceItem, err := client.InvoiceItems.Get(d.Id(), params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if invoiceItem.Customer != nil {
+		d.Set("customer", invoiceItem.Customer.ID)
+	}
+	// Items created from an amount get an inline price, which isn't kept.
+	if invoiceItem.Price != nil && d.Get("price").(string) != "" {
+		d.Set("price", invoiceItem.Price.ID)
+	}
+	d.Set("amount", invoiceItem.Amount)
+	d.Set("currency", invoiceItem.Currency)
+	d.Set("quantity", invoiceItem.Quantity)
+	d.Set("description", invoiceItem.Description)
+	if invoiceItem.Invoice != nil {
+		d.Set("invoice", invoiceItem.Invoice.ID)
+	}
+	if invoiceItem.Period != nil {
+		d.Set("period", []map[string]interface{}{
+			{
+				"start": invoiceItem.Period.Start,
+				"end":   invoiceItem.Period.End,
+			},
+		})
+	}
+	d.Set("discounts", flattenInvoiceItemDiscounts(invoiceItem.Discounts))
+	taxRates := make([]string, len(invoiceItem.TaxRates))
+	for i, taxRate := range invoiceItem.TaxRates {
+		taxRates[i] = taxRate.ID
+	}
+	d.Set("tax_rates", taxRates)
+	d.Set("metadata", invoiceItem.Metadata)
+	d.Set("date", invoiceItem.Date)
+	d.Set("livemode", invoiceItem.Livemode)
+
+	return nil
+}
+
+func resourceStripeInvoiceItemUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.InvoiceItemParams{}
+	params.Context = ctx
+
+	if d.HasChange("price") {
+		params.Price = stripe.String(d.Get("price").(string))
+	}
+
+	if d.HasChange("amount") {
+		params.Amount = stripe.Int64(int64(d.Get("amount").(int)))
+	}
+
+	if d.HasChange("quantity") {
+		params.Quantity = stripe.Int64(int64(d.Get("quantity").(int)))
+	}
+
+	if d.HasChange("description") {
+		params.Description = stripe.String(d.Get("description").(string))
+	}
+
+	if d.HasChange("period") {
+		params.Period = expandInvoiceItemPeriod(d.Get("period").([]interface{}))
+	}
+
+	if d.HasChange("discounts") {
+		if err := addInvoiceItemDiscounts(params, d.Get("discounts").([]interface{})); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("tax_rates") {
+		if taxRates := expandStringList(d, "tax_rates"); taxRates != nil {
+			params.TaxRates = taxRates
+		} else {
+			params.AddExtra("tax_rates", "")
+		}
+	}
+
+	if d.HasChange("metadata") {
+		metadata, diags := expandMetadata(d)
+		if diags.HasError() {
+			return diags
+		}
+		params.Metadata = metadata
+	}
+
+	if _, err := client.InvoiceItems.Update(d.Id(), params); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStripeInvoiceItemRead(ctx, d, m)
+}
+
+func resourceStripeInvoiceItemDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.InvoiceItemParams{}
+	params.Context = ctx
+
+	if _, err := client.InvoiceItems.Del(d.Id(), params); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
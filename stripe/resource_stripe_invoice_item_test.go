@@ -0,0 +1,85 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceStripeInvoiceItemDiscountsAndTaxRatesRoundTrip(t *testing.T) {
+	m := testProviderMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			if err := r.ParseForm(); err != nil {
+				t.Fatal(err)
+			}
+			for key, want := range map[string]string{
+				"price":                        "price_123",
+				"discounts[0][coupon]":         "SUMMER",
+				"discounts[1][promotion_code]": "promo_123",
+				"tax_rates[0]":                 "txr_123",
+			} {
+				if got := r.PostForm.Get(key); got != want {
+					t.Errorf("%s: expected %q, got %q", key, want, got)
+				}
+			}
+		}
+		fmt.Fprint(w, `{
+			"id": "ii_123",
+			"object": "invoiceitem",
+			"customer": "cus_123",
+			"amount": 1500,
+			"currency": "usd",
+			"quantity": 1,
+			"description": "Monthly seat",
+			"period": {"start": 1609459200, "end": 1612137600},
+			"price": {"id": "price_123", "object": "price", "type": "recurring", "recurring": {"interval": "month", "interval_count": 1}},
+			"discounts": [
+				{"id": "di_1", "object": "discount", "coupon": {"id": "SUMMER", "object": "coupon"}},
+				{"id": "di_2", "object": "discount", "coupon": {"id": "WINTER", "object": "coupon"}, "promotion_code": {"id": "promo_123", "object": "promotion_code"}}
+			],
+			"tax_rates": [{"id": "txr_123", "object": "tax_rate", "percentage": 20}]
+		}`)
+	})
+
+	// Invoice items of recurring prices are billed for a single period.
+	config := map[string]interface{}{
+		"customer": "cus_123",
+		"price":    "price_123",
+		"discounts": []interface{}{
+			map[string]interface{}{"coupon": "SUMMER"},
+			map[string]interface{}{"promotion_code": "promo_123"},
+		},
+		"tax_rates": []interface{}{"txr_123"},
+	}
+
+	r := resourceStripeInvoiceItem()
+	d := testResourceCreateData(t, r, config)
+
+	if diags := r.CreateContext(context.Background(), d, m); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	for key, want := range map[string]interface{}{
+		"price":                      "price_123",
+		"discounts.0.coupon":         "SUMMER",
+		"discounts.1.promotion_code": "promo_123",
+		"discounts.1.coupon":         "",
+		"tax_rates.0":                "txr_123",
+		"period.0.start":             1609459200,
+	} {
+		if got := d.Get(key); got != want {
+			t.Errorf("%s: expected %v, got %v", key, want, got)
+		}
+	}
+
+	diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !diff.Empty() {
+		t.Errorf("expected no diff, got %v", diff.Attributes)
+	}
+}
@@ -0,0 +1,73 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceStripeInvoiceFinalizeDiff(t *testing.T) {
+	cases := []struct {
+		name     string
+		status   string
+		finalize bool
+		diff     bool
+	}{
+		{
+			name:   "auto-finalized",
+			status: "open",
+		},
+		{
+			name:     "imported as paid",
+			status:   "paid",
+			finalize: true,
+		},
+		{
+			name:     "finalized draft",
+			status:   "draft",
+			finalize: true,
+			diff:     true,
+		},
+		{
+			name:   "draft",
+			status: "draft",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := testProviderMeta(t, func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprintf(w, `{"id": "in_123", "object": "invoice", "customer": "cus_123", "auto_advance": true, "status": %q}`, tc.status)
+			})
+
+			r := resourceStripeInvoice()
+			d := r.TestResourceData()
+			d.SetId("in_123")
+			d.Set("customer", "cus_123")
+			d.Set("auto_advance", true)
+			d.Set("finalize", false)
+
+			if diags := r.ReadContext(context.Background(), d, m); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"customer":     "cus_123",
+				"auto_advance": true,
+				"finalize":     tc.finalize,
+			})
+
+			diff, err := r.Diff(context.Background(), d.State(), config, m)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if hasDiff := diff != nil && !diff.Empty(); hasDiff != tc.diff {
+				t.Errorf("expected a diff: %t, got %#v", tc.diff, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,377 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	stripe "github.com/stripe/stripe-go/v72"
+	"github.com/stripe/stripe-go/v72/client"
+)
+
+func resourceStripePaymentLink() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceStripePaymentLinkCreate,
+		ReadContext:   resourceStripePaymentLinkRead,
+		UpdateContext: resourceStripePaymentLinkUpdate,
+		DeleteContext: resourceStripePaymentLinkDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateCheckingLivemode(resourceStripePaymentLinkLivemode),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"line_items": {
+				Type: schema.TypeList,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						// Stripe doesn't allow adding, removing or changing
+						// the price of line items once the link is created.
+						"price": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"quantity": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+						"adjustable_quantity": {
+							Type: schema.TypeList,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"enabled": {
+										Type:     schema.TypeBool,
+										Required: true,
+									},
+									"minimum": {
+										Type:     schema.TypeInt,
+										Optional: true,
+									},
+									"maximum": {
+										Type:     schema.TypeInt,
+										Optional: true,
+									},
+								},
+							},
+							MaxItems: 1,
+							Optional: true,
+						},
+					},
+				},
+				MinItems: 1,
+				MaxItems: 20,
+				Required: true,
+			},
+			"after_completion": {
+				Type: schema.TypeList,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateEnum("hosted_confirmation", "redirect"),
+						},
+						"custom_message": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"redirect_url": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+				MaxItems: 1,
+				Optional: true,
+				Computed: true,
+			},
+			"allow_promotion_codes": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"automatic_tax": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"metadata": {
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional: true,
+			},
+			// Computed
+			"url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"active": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"livemode": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func expandPaymentLinkLineItems(in []interface{}, update bool) []*stripe.PaymentLinkLineItemParams {
+	out := make([]*stripe.PaymentLinkLineItemParams, 0, len(in))
+	for _, v := range in {
+		item := v.(map[string]interface{})
+		params := &stripe.PaymentLinkLineItemParams{
+			Quantity: stripe.Int64(int64(item["quantity"].(int))),
+		}
+
+		// Updates refer to existing line items, which can't change price.
+		if update {
+			params.ID = stripe.String(item["id"].(string))
+		} else {
+			params.Price = stripe.String(item["price"].(string))
+		}
+
+		if adjustable, ok := item["adjustable_quantity"].([]interface{}); ok && len(adjustable) > 0 && adjustable[0] != nil {
+			a := adjustable[0].(map[string]interface{})
+			params.AdjustableQuantity = &stripe.PaymentLinkLineItemAdjustableQuantityParams{
+				Enabled: stripe.Bool(a["enabled"].(bool)),
+			}
+			if minimum := a["minimum"].(int); minimum > 0 {
+				params.AdjustableQuantity.Minimum = stripe.Int64(int64(minimum))
+			}
+			if maximum := a["maximum"].(int); maximum > 0 {
+				params.AdjustableQuantity.Maximum = stripe.Int64(int64(maximum))
+			}
+		}
+
+		out = append(out, params)
+	}
+	return out
+}
+
+// flattenPaymentLinkLineItems keeps the adjustable quantity settings from the
+// state since Stripe doesn't return them with the line items.
+func flattenPaymentLinkLineItems(in []*stripe.LineItem, current []interface{}) []map[string]interface{} {
+	adjustable := make(map[string]interface{})
+	for _, v := range current {
+		if item, ok := v.(map[string]interface{}); ok {
+			adjustable[item["price"].(string)] = item["adjustable_quantity"]
+		}
+	}
+
+	out := make([]map[string]interface{}, 0, len(in))
+	for _, item := range in {
+		price := ""
+		if item.Price != nil {
+			price = item.Price.ID
+		}
+
+		out = append(out, map[string]interface{}{
+			"id":                  item.ID,
+			"price":               price,
+			"quantity":            item.Quantity,
+			"adjustable_quantity": adjustable[price],
+		})
+	}
+	return out
+}
+
+func expandPaymentLinkAfterCompletion(in []interface{}) *stripe.PaymentLinkAfterCompletionParams {
+	if len(in) == 0 || in[0] == nil {
+		return nil
+	}
+
+	afterCompletion := in[0].(map[string]interface{})
+	out := &stripe.PaymentLinkAfterCompletionParams{
+		Type: stripe.String(afterCompletion["type"].(string)),
+	}
+
+	switch *out.Type {
+	case "hosted_confirmation":
+		if message := afterCompletion["custom_message"].(string); message != "" {
+			out.HostedConfirmation = &stripe.PaymentLinkAfterCompletionHostedConfirmationParams{
+				CustomMessage: stripe.String(message),
+			}
+		}
+	case "redirect":
+		out.Redirect = &stripe.PaymentLinkAfterCompletionRedirectParams{
+			URL: stripe.String(afterCompletion["redirect_url"].(string)),
+		}
+	}
+
+	return out
+}
+
+func flattenPaymentLinkAfterCompletion(in *stripe.PaymentLinkAfterCompletion) []map[string]interface{} {
+	if in == nil {
+		return []map[string]interface{}{}
+	}
+
+	out := map[string]interface{}{
+		"type": string(in.Type),
+	}
+	if in.HostedConfirmation != nil {
+		out["custom_message"] = in.HostedConfirmation.CustomMessage
+	}
+	if in.Redirect != nil {
+		out["redirect_url"] = in.Redirect.URL
+	}
+
+	return []map[string]interface{}{out}
+}
+
+func resourceStripePaymentLinkCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.PaymentLinkParams{
+		LineItems:           expandPaymentLinkLineItems(d.Get("line_items").([]interface{}), false),
+		AllowPromotionCodes: stripe.Bool(d.Get("allow_promotion_codes").(bool)),
+		AutomaticTax: &stripe.PaymentLinkAutomaticTaxParams{
+			Enabled: stripe.Bool(d.Get("automatic_tax").(bool)),
+		},
+	}
+	params.Context = ctx
+
+	if afterCompletion, ok := d.GetOk("after_completion"); ok {
+		params.AfterCompletion = expandPaymentLinkAfterCompletion(afterCompletion.([]interface{}))
+	}
+
+	metadata, diags := expandMetadata(d)
+	if diags.HasError() {
+		return diags
+	}
+	params.Metadata = metadata
+
+	setIdempotencyKey(params, d)
+	paymentLink, err := client.PaymentLinks.New(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Create payment link: %s (%s)", paymentLink.ID, paymentLink.URL)
+	d.SetId(paymentLink.ID)
+
+	return resourceStripePaymentLinkRead(ctx, d, m)
+}
+
+func resourceStripePaymentLinkLivemode(ctx context.Context, client *client.API, id string) (bool, error) {
+	params := &stripe.PaymentLinkParams{}
+	params.Context = ctx
+
+	paymentLink, err := client.PaymentLinks.Get(id, params)
+	if err != nil {
+		return false, err
+	}
+
+	return paymentLink.Livemode, nil
+}
+
+func listPaymentLinkLineItems(ctx context.Context, client *client.API, paymentLink string) ([]*stripe.LineItem, error) {
+	params := &stripe.PaymentLinkListLineItemsParams{
+		PaymentLink: stripe.String(paymentLink),
+	}
+	params.Context = ctx
+
+	items, err := listAll[*stripe.LineItem](ctx, client.PaymentLinks.ListLineItems(params), 0, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list the line items of payment link %s: %s", paymentLink, err)
+	}
+
+	return items, nil
+}
+
+func resourceStripePaymentLinkRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.PaymentLinkParams{}
+	params.Context = ctx
+
+	paymentLink, err := client.PaymentLinks.Get(d.Id(), params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	items, err := listPaymentLinkLineItems(ctx, client, paymentLink.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("line_items", flattenPaymentLinkLineItems(items, d.Get("line_items").([]interface{})))
+	d.Set("after_completion", flattenPaymentLinkAfterCompletion(paymentLink.AfterCompletion))
+	d.Set("allow_promotion_codes", paymentLink.AllowPromotionCodes)
+	if paymentLink.AutomaticTax != nil {
+		d.Set("automatic_tax", paymentLink.AutomaticTax.Enabled)
+	}
+	d.Set("metadata", paymentLink.Metadata)
+	d.Set("url", paymentLink.URL)
+	d.Set("active", paymentLink.Active)
+	d.Set("livemode", paymentLink.Livemode)
+
+	return nil
+}
+
+func resourceStripePaymentLinkUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.PaymentLinkParams{}
+	params.Context = ctx
+
+	if d.HasChange("line_items") {
+		params.LineItems = expandPaymentLinkLineItems(d.Get("line_items").([]interface{}), true)
+	}
+
+	if d.HasChange("after_completion") {
+		params.AfterCompletion = expandPaymentLinkAfterCompletion(d.Get("after_completion").([]interface{}))
+	}
+
+	if d.HasChange("allow_promotion_codes") {
+		params.AllowPromotionCodes = stripe.Bool(d.Get("allow_promotion_codes").(bool))
+	}
+
+	if d.HasChange("automatic_tax") {
+		params.AutomaticTax = &stripe.PaymentLinkAutomaticTaxParams{
+			Enabled: stripe.Bool(d.Get("automatic_tax").(bool)),
+		}
+	}
+
+	if d.HasChange("metadata") {
+		metadata, diags := expandMetadata(d)
+		if diags.HasError() {
+			return diags
+		}
+		params.Metadata = metadata
+	}
+
+	if _, err := client.PaymentLinks.Update(d.Id(), params); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStripePaymentLinkRead(ctx, d, m)
+}
+
+func resourceStripePaymentLinkDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	// Stripe doesn't allow deleting payment links, deactivate it instead.
+	params := &stripe.PaymentLinkParams{
+		Active: stripe.Bool(false),
+	}
+	params.Context = ctx
+
+	if _, err := client.PaymentLinks.Update(d.Id(), params); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
@@ -19,7 +19,7 @@ func resourceStripePlan() *schema.Resource {
 		UpdateContext: resourceStripePlanUpdate,
 		DeleteContext: resourceStripePlanDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateCheckingLivemode(resourceStripePlanLivemode),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -49,14 +49,17 @@ func resourceStripePlan() *schema.Resource {
 				ConflictsWith: []string{"amount"},
 			},
 			"currency": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCurrency,
+				StateFunc:    lowercaseString,
 			},
 			"interval": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateInterval,
 			},
 			"product": {
 				Type:     schema.TypeString,
@@ -68,10 +71,11 @@ func resourceStripePlan() *schema.Resource {
 				ForceNew: true,
 			},
 			"billing_scheme": {
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
-				Default:  "per_unit",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "per_unit",
+				ValidateFunc: validateEnum("per_unit", "tiered"),
 			},
 			"interval_count": {
 				Type:     schema.TypeInt,
@@ -164,25 +168,32 @@ func resourceStripePlan() *schema.Resource {
 				Optional: true,
 			},
 			"usage_type": {
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
-				Default:  "licensed",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "licensed",
+				ValidateFunc: validateUsageType,
+			},
+			// Computed
+			"created": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"livemode": {
+				Type:     schema.TypeBool,
+				Computed: true,
 			},
 		},
 	}
 }
 
 func resourceStripePlanCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*client.API)
+	client := m.(*providerMeta).API
 	planNickname := d.Get("nickname").(string)
 	planInterval := d.Get("interval").(string)
 	planCurrency := d.Get("currency").(string)
 	planProductID := d.Get("product").(string)
 
-	// TODO: check interval
-	// TODO: check currency
-
 	params := &stripe.PlanParams{
 		Interval:  stripe.String(planInterval),
 		ProductID: stripe.String(planProductID),
@@ -223,7 +234,11 @@ func resourceStripePlanCreate(ctx context.Context, d *schema.ResourceData, m int
 		params.IntervalCount = stripe.Int64(int64(intervalCount.(int)))
 	}
 
-	params.Metadata = expandMetadata(d)
+	metadata, diags := expandMetadata(d)
+	if diags.HasError() {
+		return diags
+	}
+	params.Metadata = metadata
 
 	if _, ok := d.GetOk("nickname"); ok {
 		params.Nickname = stripe.String(planNickname)
@@ -252,6 +267,7 @@ func resourceStripePlanCreate(ctx context.Context, d *schema.ResourceData, m int
 		params.UsageType = stripe.String(usageType.(string))
 	}
 
+	setIdempotencyKey(params, d)
 	plan, err := client.Plans.New(params)
 	if err != nil {
 		return diag.FromErr(err)
@@ -265,17 +281,36 @@ func resourceStripePlanCreate(ctx context.Context, d *schema.ResourceData, m int
 
 	d.SetId(plan.ID)
 
-	return nil
+	return resourceStripePlanRead(ctx, d, m)
+}
+
+func resourceStripePlanLivemode(ctx context.Context, client *client.API, id string) (bool, error) {
+	params := &stripe.PlanParams{}
+	params.Context = ctx
+
+	plan, err := client.Plans.Get(id, params)
+	if err != nil {
+		return false, err
+	}
+
+	return plan.Livemode, nil
 }
 
 func resourceStripePlanRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*client.API)
+	client := m.(*providerMeta).API
 
 	params := &stripe.PlanParams{}
 	params.Context = ctx
 	params.AddExpand("tiers")
 
+	tiersExpanded := true
 	plan, err := client.Plans.Get(d.Id(), params)
+	if err != nil && isExpansionError(err) {
+		log.Printf("[WARN] Unable to expand tiers of plan %s, reading it without them: %s", d.Id(), err)
+		tiersExpanded = false
+		params.Expand = nil
+		plan, err = client.Plans.Get(d.Id(), params)
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -291,12 +326,18 @@ func resourceStripePlanRead(ctx context.Context, d *schema.ResourceData, m inter
 	d.Set("interval_count", plan.IntervalCount)
 	d.Set("metadata", plan.Metadata)
 	d.Set("nickname", plan.Nickname)
-	d.Set("product", plan.Product)
+	if plan.Product != nil {
+		d.Set("product", plan.Product.ID)
+	}
 	d.Set("tiers_mode", plan.TiersMode)
-	d.Set("tier", flattenPlanTiers(plan.Tiers))
+	if tiersExpanded {
+		d.Set("tier", flattenPlanTiers(plan.Tiers))
+	}
 	d.Set("transform_usage", flattenPlanTransformUsage(plan.TransformUsage))
 	d.Set("trial_period_days", plan.TrialPeriodDays)
 	d.Set("usage_type", plan.UsageType)
+	d.Set("created", plan.Created)
+	d.Set("livemode", plan.Livemode)
 
 	return nil
 }
@@ -304,9 +345,11 @@ func resourceStripePlanRead(ctx context.Context, d *schema.ResourceData, m inter
 func flattenPlanTiers(in []*stripe.PlanTier) []map[string]interface{} {
 	out := make([]map[string]interface{}, len(in))
 	for i, tier := range in {
+		// Stripe returns a null up_to for the unbounded tier, which can only
+		// be the last one.
 		out[i] = map[string]interface{}{
 			"up_to":               tier.UpTo,
-			"up_to_inf":           tier.UpTo == 0,
+			"up_to_inf":           i == len(in)-1 && tier.UpTo == 0,
 			"flat_amount":         tier.FlatAmount,
 			"flat_amount_decimal": tier.FlatAmountDecimal,
 			"unit_amount":         tier.UnitAmount,
@@ -330,18 +373,30 @@ func expandPlanTier(d *schema.ResourceData, idx int) (*stripe.PlanTierParams, di
 	}
 
 	if upToOK && upToInfOK {
-		return nil, diag.Errorf("up_to: conflicts with up_to_inf")
+		return nil, diag.Errorf("tier.%d.up_to: conflicts with up_to_inf", idx)
+	}
+
+	flatAmount, flatAmountOK := d.GetOk(fmt.Sprintf("tier.%d.flat_amount", idx))
+	flatAmountDecimal, flatAmountDecimalOK := d.GetOk(fmt.Sprintf("tier.%d.flat_amount_decimal", idx))
+	if flatAmountOK && flatAmountDecimalOK {
+		return nil, diag.Errorf("tier.%d.flat_amount: conflicts with flat_amount_decimal", idx)
 	}
 
-	if flatAmount, ok := d.GetOk(fmt.Sprintf("tier.%d.flat_amount", idx)); ok {
+	if flatAmountOK {
 		params.FlatAmount = stripe.Int64(int64(flatAmount.(int)))
-	} else if flatAmountDecimal, ok := d.GetOk(fmt.Sprintf("tier.%d.flat_amount_decimal", idx)); ok {
+	} else if flatAmountDecimalOK {
 		params.FlatAmountDecimal = stripe.Float64(flatAmountDecimal.(float64))
 	}
 
-	if unitAmount, ok := d.GetOk(fmt.Sprintf("tier.%d.unit_amount", idx)); ok {
+	unitAmount, unitAmountOK := d.GetOk(fmt.Sprintf("tier.%d.unit_amount", idx))
+	unitAmountDecimal, unitAmountDecimalOK := d.GetOk(fmt.Sprintf("tier.%d.unit_amount_decimal", idx))
+	if unitAmountOK && unitAmountDecimalOK {
+		return nil, diag.Errorf("tier.%d.unit_amount: conflicts with unit_amount_decimal", idx)
+	}
+
+	if unitAmountOK {
 		params.UnitAmount = stripe.Int64(int64(unitAmount.(int)))
-	} else if unitAmountDecimal, ok := d.GetOk(fmt.Sprintf("tier.%d.unit_amount_decimal", idx)); ok {
+	} else if unitAmountDecimalOK {
 		params.UnitAmountDecimal = stripe.Float64(unitAmountDecimal.(float64))
 	}
 
@@ -395,7 +450,7 @@ func expandPlanTransformUsage(in []interface{}) *stripe.PlanTransformUsageParams
 }
 
 func resourceStripePlanUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*client.API)
+	client := m.(*providerMeta).API
 
 	params := &stripe.PlanParams{}
 	params.Context = ctx
@@ -409,13 +464,21 @@ func resourceStripePlanUpdate(ctx context.Context, d *schema.ResourceData, m int
 	}
 
 	if d.HasChange("metadata") {
-		params.Metadata = expandMetadata(d)
+		metadata, diags := expandMetadata(d)
+		if diags.HasError() {
+			return diags
+		}
+		params.Metadata = metadata
 	}
 
 	if d.HasChange("nickname") {
 		params.Nickname = stripe.String(d.Get("nickname").(string))
 	}
 
+	if d.HasChange("product") {
+		params.ProductID = stripe.String(d.Get("product").(string))
+	}
+
 	if d.HasChange("trial_period_days") {
 		params.TrialPeriodDays = stripe.Int64(int64(d.Get("trial_period_days").(int)))
 	}
@@ -428,7 +491,7 @@ func resourceStripePlanUpdate(ctx context.Context, d *schema.ResourceData, m int
 }
 
 func resourceStripePlanDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*client.API)
+	client := m.(*providerMeta).API
 
 	params := &stripe.PlanParams{}
 	params.Context = ctx
@@ -0,0 +1,178 @@
+package stripe
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+func TestFlattenPlanTiers(t *testing.T) {
+	cases := []struct {
+		name  string
+		tiers string
+		want  []bool
+	}{
+		{
+			name:  "unbounded last tier",
+			tiers: `[{"up_to": 10, "unit_amount": 500}, {"up_to": null, "unit_amount": 400}]`,
+			want:  []bool{false, true},
+		},
+		{
+			name:  "finite last tier",
+			tiers: `[{"up_to": 10, "unit_amount": 500}, {"up_to": 100, "unit_amount": 400}]`,
+			want:  []bool{false, false},
+		},
+		{
+			name:  "single unbounded tier",
+			tiers: `[{"up_to": null, "flat_amount": 1000}]`,
+			want:  []bool{true},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var tiers []*stripe.PlanTier
+			if err := json.Unmarshal([]byte(tc.tiers), &tiers); err != nil {
+				t.Fatal(err)
+			}
+
+			got := make([]bool, 0, len(tiers))
+			for _, tier := range flattenPlanTiers(tiers) {
+				got = append(got, tier["up_to_inf"].(bool))
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected up_to_inf %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestResourceStripePlanCreateReadsBack(t *testing.T) {
+	m := testProviderMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "plan_123", "object": "plan", "active": true, "amount": 1500, "billing_scheme": "per_unit", "currency": "usd", "interval": "month", "interval_count": 1, "usage_type": "licensed", "product": "prod_123", "created": 1609459200, "livemode": true}`)
+	})
+
+	r := resourceStripePlan()
+	d := testResourceCreateData(t, r, map[string]interface{}{
+		"amount":   1500,
+		"currency": "usd",
+		"interval": "month",
+		"product":  "prod_123",
+	})
+
+	if diags := r.CreateContext(context.Background(), d, m); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if got := d.Get("created").(int); got != 1609459200 {
+		t.Errorf("expected created 1609459200, got %d", got)
+	}
+	if got := d.Get("livemode").(bool); !got {
+		t.Errorf("expected livemode to be read back")
+	}
+}
+
+func TestResourceStripePlanReadWithRestrictedKey(t *testing.T) {
+	requests := 0
+	m := testProviderMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Query().Get("expand[0]") != "" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error": {"type": "invalid_request_error", "param": "expand[0]", "message": "This property cannot be expanded (tiers)."}}`)
+			return
+		}
+		fmt.Fprint(w, `{"id": "plan_123", "object": "plan", "active": true, "currency": "usd", "interval": "month", "billing_scheme": "tiered", "tiers_mode": "volume", "product": "prod_123"}`)
+	})
+
+	r := resourceStripePlan()
+	d := r.TestResourceData()
+	d.SetId("plan_123")
+	d.Set("tier", []interface{}{
+		map[string]interface{}{"up_to": 10, "unit_amount": 100},
+		map[string]interface{}{"up_to_inf": true, "unit_amount": 50},
+	})
+
+	if diags := r.ReadContext(context.Background(), d, m); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if requests != 2 {
+		t.Errorf("expected the plan to be read again without expansion, got %d requests", requests)
+	}
+	if got := d.Get("tiers_mode"); got != "volume" {
+		t.Errorf("expected tiers_mode volume, got %v", got)
+	}
+	if got := d.Get("tier.#").(int); got != 2 {
+		t.Errorf("expected the tiers to be left as they were, got %d", got)
+	}
+}
+
+func TestResourceStripePlanMoveToAnotherProduct(t *testing.T) {
+	product := "prod_a"
+	m := testProviderMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/plans/plan_123" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+
+		if r.Method == http.MethodPost {
+			if err := r.ParseForm(); err != nil {
+				t.Fatal(err)
+			}
+			if v, ok := r.PostForm["product"]; ok {
+				product = v[0]
+			}
+		}
+
+		fmt.Fprintf(w, `{"id": "plan_123", "object": "plan", "active": true, "amount": 1500, "billing_scheme": "per_unit", "currency": "usd", "interval": "month", "interval_count": 1, "usage_type": "licensed", "product": %q}`, product)
+	})
+
+	r := resourceStripePlan()
+	state := r.TestResourceData()
+	state.SetId("plan_123")
+	state.Set("plan_id", "plan_123")
+	state.Set("active", true)
+	state.Set("amount", 1500)
+	state.Set("billing_scheme", "per_unit")
+	state.Set("currency", "usd")
+	state.Set("interval", "month")
+	state.Set("interval_count", 1)
+	state.Set("usage_type", "licensed")
+	state.Set("product", "prod_a")
+
+	diff, err := r.Diff(context.Background(), state.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"plan_id":  "plan_123",
+		"amount":   1500,
+		"currency": "usd",
+		"interval": "month",
+		"product":  "prod_b",
+	}), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff.RequiresNew() {
+		t.Fatal("expected the plan to be updated in place")
+	}
+
+	d, err := schema.InternalMap(r.Schema).Data(state.State(), diff)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diags := r.UpdateContext(context.Background(), d, m); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if product != "prod_b" {
+		t.Errorf("expected Stripe to receive the new product, got %q", product)
+	}
+	if got := d.Get("product"); got != "prod_b" {
+		t.Errorf("expected product to read back as prod_b, got %q", got)
+	}
+}
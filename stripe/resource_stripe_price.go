@@ -2,14 +2,15 @@ package stripe
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	stripe "github.com/stripe/stripe-go/v72"
 	"github.com/stripe/stripe-go/v72/client"
 )
@@ -21,7 +22,7 @@ func resourceStripePrice() *schema.Resource {
 		UpdateContext: resourceStripePriceUpdate,
 		DeleteContext: resourceStripePriceDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateCheckingLivemode(resourceStripePriceLivemode),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -36,33 +37,58 @@ func resourceStripePrice() *schema.Resource {
 				Optional: true,
 				Default:  true,
 			},
+			"treat_archived_as_missing": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			"currency": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCurrency,
+				StateFunc:    lowercaseString,
 			},
 			"metadata": {
 				Type: schema.TypeMap,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
-				Optional: true,
+				Optional:         true,
+				DiffSuppressFunc: suppressEquivalentMetadata,
 			},
 			"nickname": {
 				Type:     schema.TypeString,
 				Optional: true,
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return strings.TrimSpace(old) == strings.TrimSpace(new)
+				},
+			},
+			"lookup_key": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			// Moves the lookup key from the price currently holding it.
+			"transfer_lookup_key": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
 			},
 			"product": {
 				Type:     schema.TypeString,
 				Optional: true,
 				ForceNew: true,
 			},
+			// Stripe doesn't allow changing the recurring terms of a price,
+			// a new price has to be created instead.
 			"recurring": {
 				Type: schema.TypeMap,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
-				Optional: true,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validatePriceRecurring,
 			},
 			"unit_amount": {
 				Type:     schema.TypeInt,
@@ -76,10 +102,14 @@ func resourceStripePrice() *schema.Resource {
 				Optional: true,
 				ForceNew: true,
 			},
+			// Lets customers choose the amount, e.g. for donations.
+			"custom_unit_amount": priceCustomUnitAmountSchema(true, "unit_amount", "unit_amount_decimal", "tier"),
 			"billing_scheme": {
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true, // per_unit when omitted
+				ForceNew:     true,
+				ValidateFunc: validateEnum("per_unit", "tiered"),
 			},
 			"created": {
 				Type:     schema.TypeInt,
@@ -141,20 +171,82 @@ func resourceStripePrice() *schema.Resource {
 				Type:         schema.TypeString,
 				Optional:     true,
 				Default:      "unspecified",
-				ValidateFunc: validation.StringInSlice([]string{"unspecified", "inclusive", "exclusive"}, false),
+				ValidateFunc: validateTaxBehavior,
+			},
+			// Amounts in currencies other than the price's one.
+			"currency_options": {
+				Type: schema.TypeSet,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"currency": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateCurrency,
+							StateFunc:    lowercaseString,
+						},
+						"unit_amount": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"unit_amount_decimal": {
+							Type:     schema.TypeFloat,
+							Optional: true,
+						},
+						"tax_behavior": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "unspecified",
+							ValidateFunc: validateTaxBehavior,
+						},
+						"custom_unit_amount": priceCustomUnitAmountSchema(false),
+					},
+				},
+				Optional: true,
 			},
 		},
 		CustomizeDiff: customdiff.All(
 			customdiff.ForceNewIfChange("tax_behavior", func(ctx context.Context, old, new, meta interface{}) bool {
 				return old != "unspecified"
 			}),
+			func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+				if !d.NewValueKnown("recurring") {
+					return nil
+				}
+
+				recurring := d.Get("recurring").(map[string]interface{})
+				if _, ok := recurring["meter"]; ok && recurring["usage_type"] != "metered" {
+					return fmt.Errorf("recurring: meter can only be set when usage_type is \"metered\"")
+				}
+				return nil
+			},
 		),
 	}
 }
 
+// validatePriceRecurring checks the interval and usage type at plan time, the
+// map's other values are left to expandPriceRecurring.
+func validatePriceRecurring(i interface{}, k string) (warnings []string, errors []error) {
+	recurring := i.(map[string]interface{})
+
+	if interval, ok := recurring["interval"]; ok {
+		_, errs := validateInterval(interval, k+".interval")
+		errors = append(errors, errs...)
+	}
+
+	if usageType, ok := recurring["usage_type"]; ok {
+		_, errs := validateUsageType(usageType, k+".usage_type")
+		errors = append(errors, errs...)
+	}
+
+	return warnings, errors
+}
+
 func expandPriceRecurring(recurring map[string]interface{}) (*stripe.PriceRecurringParams, diag.Diagnostics) {
 	params := &stripe.PriceRecurringParams{}
-	parsed := expandStringMap(recurring)
+	parsed, err := expandStringMap(recurring)
+	if err != nil {
+		return nil, diag.Errorf("recurring: %s", err)
+	}
 
 	if aggregateUsage, ok := parsed["aggregate_usage"]; ok {
 		params.AggregateUsage = stripe.String(aggregateUsage)
@@ -179,8 +271,188 @@ func expandPriceRecurring(recurring map[string]interface{}) (*stripe.PriceRecurr
 	return params, nil
 }
 
+// readPriceMeter returns the billing meter of a price, which stripe-go doesn't
+// know about, from the raw response.
+func readPriceMeter(price *stripe.Price) (string, error) {
+	if price.LastResponse == nil {
+		return "", nil
+	}
+
+	var raw struct {
+		Recurring *struct {
+			Meter string `json:"meter"`
+		} `json:"recurring"`
+	}
+	if err := json.Unmarshal(price.LastResponse.RawJSON, &raw); err != nil {
+		return "", err
+	}
+
+	if raw.Recurring == nil {
+		return "", nil
+	}
+	return raw.Recurring.Meter, nil
+}
+
+var priceRecurringDefaults = map[string]string{
+	"aggregate_usage": "sum",
+	"interval_count":  "1",
+	"usage_type":      "licensed",
+}
+
+func flattenPriceRecurring(in *stripe.PriceRecurring) map[string]string {
+	out := make(map[string]string)
+	if in == nil {
+		return out
+	}
+
+	if in.AggregateUsage != "" {
+		out["aggregate_usage"] = string(in.AggregateUsage)
+	}
+	if in.Interval != "" {
+		out["interval"] = string(in.Interval)
+	}
+	if in.IntervalCount != 0 {
+		out["interval_count"] = strconv.FormatInt(in.IntervalCount, 10)
+	}
+	if in.UsageType != "" {
+		out["usage_type"] = string(in.UsageType)
+	}
+
+	return out
+}
+
+func priceCustomUnitAmountSchema(forceNew bool, conflictsWith ...string) *schema.Schema {
+	return &schema.Schema{
+		Type: schema.TypeList,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"enabled": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  true,
+					ForceNew: forceNew,
+				},
+				"maximum": {
+					Type:     schema.TypeInt,
+					Optional: true,
+					ForceNew: forceNew,
+				},
+				"minimum": {
+					Type:     schema.TypeInt,
+					Optional: true,
+					ForceNew: forceNew,
+				},
+				"preset": {
+					Type:     schema.TypeInt,
+					Optional: true,
+					ForceNew: forceNew,
+				},
+			},
+		},
+		MaxItems:      1,
+		Optional:      true,
+		ForceNew:      forceNew,
+		ConflictsWith: conflictsWith,
+	}
+}
+
+func flattenPriceCustomUnitAmount(maximum, minimum, preset int64) []map[string]interface{} {
+	return []map[string]interface{}{
+		{
+			"enabled": true,
+			"maximum": maximum,
+			"minimum": minimum,
+			"preset":  preset,
+		},
+	}
+}
+
+func expandPriceCurrencyOptions(in []interface{}) (map[string]*stripe.PriceCurrencyOptionsParams, diag.Diagnostics) {
+	out := make(map[string]*stripe.PriceCurrencyOptionsParams, len(in))
+	for _, v := range in {
+		option := v.(map[string]interface{})
+		currency := strings.ToLower(option["currency"].(string))
+
+		params := &stripe.PriceCurrencyOptionsParams{
+			TaxBehavior: stripe.String(option["tax_behavior"].(string)),
+		}
+
+		unitAmount := option["unit_amount"].(int)
+		unitAmountDecimal := option["unit_amount_decimal"].(float64)
+		if unitAmount != 0 && unitAmountDecimal != 0 {
+			return nil, diag.Errorf("currency_options.%s.unit_amount: conflicts with unit_amount_decimal", currency)
+		}
+		if unitAmountDecimal != 0 {
+			params.UnitAmountDecimal = stripe.Float64(unitAmountDecimal)
+		} else {
+			params.UnitAmount = stripe.Int64(int64(unitAmount))
+		}
+
+		if custom := option["custom_unit_amount"].([]interface{}); len(custom) > 0 && custom[0] != nil {
+			c := custom[0].(map[string]interface{})
+			params.UnitAmount = nil
+			params.CustomUnitAmount = &stripe.PriceCurrencyOptionsCustomUnitAmountParams{
+				Enabled: stripe.Bool(c["enabled"].(bool)),
+			}
+			if maximum := c["maximum"].(int); maximum != 0 {
+				params.CustomUnitAmount.Maximum = stripe.Int64(int64(maximum))
+			}
+			if minimum := c["minimum"].(int); minimum != 0 {
+				params.CustomUnitAmount.Minimum = stripe.Int64(int64(minimum))
+			}
+			if preset := c["preset"].(int); preset != 0 {
+				params.CustomUnitAmount.Preset = stripe.Int64(int64(preset))
+			}
+		}
+
+		out[currency] = params
+	}
+
+	return out, nil
+}
+
+// flattenPriceCurrencyOptions leaves out the price's own currency, which
+// Stripe lists among the options too. Decimal amounts are only kept for the
+// currencies configured with them, as Stripe always returns both variants.
+func flattenPriceCurrencyOptions(in map[string]*stripe.PriceCurrencyOptions, priceCurrency stripe.Currency, configured []interface{}) []map[string]interface{} {
+	decimal := make(map[string]bool)
+	for _, v := range configured {
+		option := v.(map[string]interface{})
+		if option["unit_amount_decimal"].(float64) != 0 {
+			decimal[strings.ToLower(option["currency"].(string))] = true
+		}
+	}
+
+	out := make([]map[string]interface{}, 0, len(in))
+	for currency, option := range in {
+		if currency == string(priceCurrency) || option == nil {
+			continue
+		}
+
+		flattened := map[string]interface{}{
+			"currency":     currency,
+			"tax_behavior": string(option.TaxBehavior),
+		}
+		if option.TaxBehavior == "" {
+			flattened["tax_behavior"] = "unspecified"
+		}
+		if decimal[currency] {
+			flattened["unit_amount_decimal"] = option.UnitAmountDecimal
+		} else {
+			flattened["unit_amount"] = option.UnitAmount
+		}
+		if option.CustomUnitAmount != nil {
+			flattened["custom_unit_amount"] = flattenPriceCustomUnitAmount(option.CustomUnitAmount.Maximum, option.CustomUnitAmount.Minimum, option.CustomUnitAmount.Preset)
+		}
+
+		out = append(out, flattened)
+	}
+
+	return out
+}
+
 func resourceStripePriceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*client.API)
+	client := m.(*providerMeta).API
 	nickname := d.Get("nickname").(string)
 	currency := d.Get("currency").(string)
 
@@ -193,12 +465,21 @@ func resourceStripePriceCreate(ctx context.Context, d *schema.ResourceData, m in
 		params.Active = stripe.Bool(active.(bool))
 	}
 
-	params.Metadata = expandMetadata(d)
+	metadata, diags := expandMetadata(d)
+	if diags.HasError() {
+		return diags
+	}
+	params.Metadata = metadata
 
 	if _, ok := d.GetOk("nickname"); ok {
 		params.Nickname = stripe.String(nickname)
 	}
 
+	if lookupKey, ok := d.GetOk("lookup_key"); ok {
+		params.LookupKey = stripe.String(lookupKey.(string))
+		params.TransferLookupKey = stripe.Bool(d.Get("transfer_lookup_key").(bool))
+	}
+
 	if tiersMode, ok := d.GetOk("tiers_mode"); ok {
 		params.TiersMode = stripe.String(tiersMode.(string))
 	}
@@ -221,6 +502,11 @@ func resourceStripePriceCreate(ctx context.Context, d *schema.ResourceData, m in
 		}
 		// TODO: Propagate non-error diagnostics
 		params.Recurring = recurringParams
+
+		// The meter isn't part of the params stripe-go knows about.
+		if meter, ok := recurring.(map[string]interface{})["meter"]; ok {
+			params.AddExtra("recurring[meter]", meter.(string))
+		}
 	}
 
 	// TODO: The `GetOkExists` method is deprecated, but there is no other way to
@@ -235,6 +521,22 @@ func resourceStripePriceCreate(ctx context.Context, d *schema.ResourceData, m in
 		params.UnitAmountDecimal = stripe.Float64(unitAmountDecimal.(float64))
 	}
 
+	if customUnitAmount, ok := d.GetOk("custom_unit_amount"); ok {
+		c := customUnitAmount.([]interface{})[0].(map[string]interface{})
+		params.CustomUnitAmount = &stripe.PriceCustomUnitAmountParams{
+			Enabled: stripe.Bool(c["enabled"].(bool)),
+		}
+		if maximum := c["maximum"].(int); maximum != 0 {
+			params.CustomUnitAmount.Maximum = stripe.Int64(int64(maximum))
+		}
+		if minimum := c["minimum"].(int); minimum != 0 {
+			params.CustomUnitAmount.Minimum = stripe.Int64(int64(minimum))
+		}
+		if preset := c["preset"].(int); preset != 0 {
+			params.CustomUnitAmount.Preset = stripe.Int64(int64(preset))
+		}
+	}
+
 	if billingScheme, ok := d.GetOk("billing_scheme"); ok {
 		params.BillingScheme = stripe.String(billingScheme.(string))
 	}
@@ -243,6 +545,14 @@ func resourceStripePriceCreate(ctx context.Context, d *schema.ResourceData, m in
 		params.TaxBehavior = stripe.String(taxBehavior.(string))
 	}
 
+	if currencyOptions, ok := d.GetOk("currency_options"); ok {
+		params.CurrencyOptions, diags = expandPriceCurrencyOptions(currencyOptions.(*schema.Set).List())
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	setIdempotencyKey(params, d)
 	price, err := client.Prices.New(params)
 	if err != nil {
 		return diag.FromErr(err)
@@ -251,21 +561,79 @@ func resourceStripePriceCreate(ctx context.Context, d *schema.ResourceData, m in
 	log.Printf("[INFO] Created Stripe price: %s", nickname)
 	d.SetId(price.ID)
 
-	return resourceStripePriceRead(ctx, d, m)
+	diags = checkPriceTaxBehavior(ctx, client, price)
+	return append(diags, resourceStripePriceRead(ctx, d, m)...)
+}
+
+// checkPriceTaxBehavior warns when a price can't be taxed automatically because
+// its product carries a tax code while the price leaves its behavior unspecified.
+func checkPriceTaxBehavior(ctx context.Context, client *client.API, price *stripe.Price) diag.Diagnostics {
+	if price.TaxBehavior != stripe.PriceTaxBehaviorUnspecified || price.Product == nil {
+		return nil
+	}
+
+	params := &stripe.ProductParams{}
+	params.Context = ctx
+
+	product, err := client.Products.Get(price.Product.ID, params)
+	if err != nil {
+		log.Printf("[WARN] Unable to check the tax code of product %s: %s", price.Product.ID, err)
+		return nil
+	}
+
+	if product.TaxCode == nil {
+		return nil
+	}
+
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  "Price tax behavior is unspecified",
+			Detail:   fmt.Sprintf("Product %s has tax code %s, but price %s doesn't set tax_behavior, so Stripe Tax can't compute taxes for it.", product.ID, product.TaxCode.ID, price.ID),
+		},
+	}
+}
+
+func resourceStripePriceLivemode(ctx context.Context, client *client.API, id string) (bool, error) {
+	params := &stripe.PriceParams{}
+	params.Context = ctx
+
+	price, err := client.Prices.Get(id, params)
+	if err != nil {
+		return false, err
+	}
+
+	return price.Livemode, nil
 }
 
 func resourceStripePriceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*client.API)
+	client := m.(*providerMeta).API
 
 	params := &stripe.PriceParams{}
 	params.Context = ctx
 	params.AddExpand("tiers")
+	params.AddExpand("currency_options")
 
+	expanded := true
 	price, err := client.Prices.Get(d.Id(), params)
+	if err != nil && isExpansionError(err) {
+		log.Printf("[WARN] Unable to expand tiers and currency options of price %s, reading it without them: %s", d.Id(), err)
+		expanded = false
+		params.Expand = nil
+		price, err = client.Prices.Get(d.Id(), params)
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	// Only an archival made outside of Terraform counts, prices and products
+	// configured as inactive are expected to be archived.
+	if !price.Active && d.Get("active").(bool) && d.Get("treat_archived_as_missing").(bool) {
+		log.Printf("[WARN] Price %s was archived, removing it from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
 	d.Set("price_id", price.ID)
 	d.Set("active", price.Active)
 	d.Set("created", price.Created)
@@ -273,14 +641,38 @@ func resourceStripePriceRead(ctx context.Context, d *schema.ResourceData, m inte
 	d.Set("livemode", price.Livemode)
 	d.Set("metadata", price.Metadata)
 	d.Set("nickname", price.Nickname)
+	d.Set("lookup_key", price.LookupKey)
 	if price.Product != nil {
 		d.Set("product", price.Product.ID)
 	}
-	d.Set("recurring", price.Active)
+	// Values Stripe defaulted are only kept when they're configured.
+	recurring := flattenPriceRecurring(price.Recurring)
+	configuredRecurring := d.Get("recurring").(map[string]interface{})
+	for key, value := range priceRecurringDefaults {
+		if _, ok := configuredRecurring[key]; !ok && recurring[key] == value {
+			delete(recurring, key)
+		}
+	}
+	meter, err := readPriceMeter(price)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if meter != "" {
+		recurring["meter"] = meter
+	}
+	d.Set("recurring", recurring)
 	d.Set("unit_amount", price.UnitAmount)
 	d.Set("unit_amount_decimal", price.UnitAmountDecimal)
 	d.Set("tiers_mode", price.TiersMode)
-	d.Set("tier", flattenPriceTiers(price.Tiers))
+	if expanded {
+		d.Set("tier", flattenPriceTiers(price.Tiers))
+		d.Set("currency_options", flattenPriceCurrencyOptions(price.CurrencyOptions, price.Currency, d.Get("currency_options").(*schema.Set).List()))
+	}
+	if price.CustomUnitAmount != nil {
+		d.Set("custom_unit_amount", flattenPriceCustomUnitAmount(price.CustomUnitAmount.Maximum, price.CustomUnitAmount.Minimum, price.CustomUnitAmount.Preset))
+	} else {
+		d.Set("custom_unit_amount", nil)
+	}
 	d.Set("billing_scheme", price.BillingScheme)
 	d.Set("tax_behavior", price.TaxBehavior)
 
@@ -290,9 +682,11 @@ func resourceStripePriceRead(ctx context.Context, d *schema.ResourceData, m inte
 func flattenPriceTiers(in []*stripe.PriceTier) []map[string]interface{} {
 	out := make([]map[string]interface{}, len(in))
 	for i, tier := range in {
+		// Stripe returns a null up_to for the unbounded tier, which can only
+		// be the last one.
 		out[i] = map[string]interface{}{
 			"up_to":               tier.UpTo,
-			"up_to_inf":           tier.UpTo == 0,
+			"up_to_inf":           i == len(in)-1 && tier.UpTo == 0,
 			"flat_amount":         tier.FlatAmount,
 			"flat_amount_decimal": tier.FlatAmountDecimal,
 			"unit_amount":         tier.UnitAmount,
@@ -316,18 +710,30 @@ func expandPriceTier(d *schema.ResourceData, idx int) (*stripe.PriceTierParams,
 	}
 
 	if upToOK && upToInfOK {
-		return nil, diag.Errorf("up_to: conflicts with up_to_inf")
+		return nil, diag.Errorf("tier.%d.up_to: conflicts with up_to_inf", idx)
 	}
 
-	if flatAmount, ok := d.GetOkExists(fmt.Sprintf("tier.%d.flat_amount", idx)); ok {
+	flatAmount, flatAmountOK := d.GetOkExists(fmt.Sprintf("tier.%d.flat_amount", idx))
+	flatAmountDecimal, flatAmountDecimalOK := d.GetOkExists(fmt.Sprintf("tier.%d.flat_amount_decimal", idx))
+	if flatAmountOK && flatAmountDecimalOK {
+		return nil, diag.Errorf("tier.%d.flat_amount: conflicts with flat_amount_decimal", idx)
+	}
+
+	if flatAmountOK {
 		params.FlatAmount = stripe.Int64(int64(flatAmount.(int)))
-	} else if flatAmountDecimal, ok := d.GetOkExists(fmt.Sprintf("tier.%d.flat_amount_decimal", idx)); ok {
+	} else if flatAmountDecimalOK {
 		params.FlatAmountDecimal = stripe.Float64(flatAmountDecimal.(float64))
 	}
 
-	if unitAmount, ok := d.GetOkExists(fmt.Sprintf("tier.%d.unit_amount", idx)); ok {
+	unitAmount, unitAmountOK := d.GetOkExists(fmt.Sprintf("tier.%d.unit_amount", idx))
+	unitAmountDecimal, unitAmountDecimalOK := d.GetOkExists(fmt.Sprintf("tier.%d.unit_amount_decimal", idx))
+	if unitAmountOK && unitAmountDecimalOK {
+		return nil, diag.Errorf("tier.%d.unit_amount: conflicts with unit_amount_decimal", idx)
+	}
+
+	if unitAmountOK {
 		params.UnitAmount = stripe.Int64(int64(unitAmount.(int)))
-	} else if unitAmountDecimal, ok := d.GetOkExists(fmt.Sprintf("tier.%d.unit_amount_decimal", idx)); ok {
+	} else if unitAmountDecimalOK {
 		params.UnitAmountDecimal = stripe.Float64(unitAmountDecimal.(float64))
 	}
 
@@ -352,7 +758,7 @@ func expandPriceTiers(d *schema.ResourceData) (out []*stripe.PriceTierParams, di
 }
 
 func resourceStripePriceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*client.API)
+	client := m.(*providerMeta).API
 
 	params := &stripe.PriceParams{}
 	params.Context = ctx
@@ -362,27 +768,58 @@ func resourceStripePriceUpdate(ctx context.Context, d *schema.ResourceData, m in
 	}
 
 	if d.HasChange("metadata") {
-		params.Metadata = expandMetadata(d)
+		metadata, diags := expandMetadata(d)
+		if diags.HasError() {
+			return diags
+		}
+		params.Metadata = metadata
 	}
 
 	if d.HasChange("nickname") {
 		params.Nickname = stripe.String(d.Get("nickname").(string))
 	}
 
+	if d.HasChange("lookup_key") {
+		params.LookupKey = stripe.String(d.Get("lookup_key").(string))
+		params.TransferLookupKey = stripe.Bool(d.Get("transfer_lookup_key").(bool))
+	}
+
 	if d.HasChange("tax_behavior") {
 		params.TaxBehavior = stripe.String(d.Get("tax_behavior").(string))
 	}
 
-	_, err := client.Prices.Update(d.Id(), params)
+	if d.HasChange("currency_options") {
+		o, n := d.GetChange("currency_options")
+		currencyOptions, diags := expandPriceCurrencyOptions(n.(*schema.Set).List())
+		if diags.HasError() {
+			return diags
+		}
+		params.CurrencyOptions = currencyOptions
+
+		// Currencies no longer configured have to be unset explicitly.
+		for _, v := range o.(*schema.Set).List() {
+			currency := strings.ToLower(v.(map[string]interface{})["currency"].(string))
+			if _, ok := currencyOptions[currency]; !ok {
+				params.AddExtra(fmt.Sprintf("currency_options[%s]", currency), "")
+			}
+		}
+	}
+
+	price, err := client.Prices.Update(d.Id(), params)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	return resourceStripePriceRead(ctx, d, m)
+	var diags diag.Diagnostics
+	if d.HasChange("tax_behavior") {
+		diags = checkPriceTaxBehavior(ctx, client, price)
+	}
+
+	return append(diags, resourceStripePriceRead(ctx, d, m)...)
 }
 
 func resourceStripePriceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*client.API)
+	client := m.(*providerMeta).API
 
 	params := &stripe.PriceParams{
 		Active: stripe.Bool(false),
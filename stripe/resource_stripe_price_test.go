@@ -0,0 +1,378 @@
+package stripe
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+func TestResourceStripePriceReadMeter(t *testing.T) {
+	m := testProviderMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "price_123", "object": "price", "active": true, "currency": "usd", "recurring": {"interval": "month", "interval_count": 1, "usage_type": "metered", "meter": "mtr_123"}}`)
+	})
+
+	r := resourceStripePrice()
+	d := r.TestResourceData()
+	d.SetId("price_123")
+
+	if diags := r.ReadContext(context.Background(), d, m); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if got := d.Get("recurring.meter"); got != "mtr_123" {
+		t.Errorf("expected meter mtr_123, got %v", got)
+	}
+}
+
+func TestResourceStripePriceCustomizeDiffMeter(t *testing.T) {
+	cases := []struct {
+		name      string
+		recurring map[string]interface{}
+		err       string
+	}{
+		{
+			name:      "metered",
+			recurring: map[string]interface{}{"interval": "month", "usage_type": "metered", "meter": "mtr_123"},
+		},
+		{
+			name:      "licensed",
+			recurring: map[string]interface{}{"interval": "month", "usage_type": "licensed", "meter": "mtr_123"},
+			err:       "meter can only be set when usage_type is \"metered\"",
+		},
+		{
+			name:      "default usage type",
+			recurring: map[string]interface{}{"interval": "month", "meter": "mtr_123"},
+			err:       "meter can only be set when usage_type is \"metered\"",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"currency":    "usd",
+				"product":     "prod_123",
+				"unit_amount": 100,
+				"recurring":   tc.recurring,
+			})
+
+			_, err := resourceStripePrice().Diff(context.Background(), nil, config, nil)
+			if tc.err == "" && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if tc.err != "" && (err == nil || !strings.Contains(err.Error(), tc.err)) {
+				t.Fatalf("expected error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestFlattenPriceTiers(t *testing.T) {
+	cases := []struct {
+		name  string
+		tiers string
+		want  []bool
+	}{
+		{
+			name:  "unbounded last tier",
+			tiers: `[{"up_to": 10, "unit_amount": 500}, {"up_to": null, "unit_amount": 400}]`,
+			want:  []bool{false, true},
+		},
+		{
+			name:  "finite last tier",
+			tiers: `[{"up_to": 10, "unit_amount": 500}, {"up_to": 100, "unit_amount": 400}]`,
+			want:  []bool{false, false},
+		},
+		{
+			name:  "single unbounded tier",
+			tiers: `[{"up_to": null, "flat_amount": 1000}]`,
+			want:  []bool{true},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var tiers []*stripe.PriceTier
+			if err := json.Unmarshal([]byte(tc.tiers), &tiers); err != nil {
+				t.Fatal(err)
+			}
+
+			got := make([]bool, 0, len(tiers))
+			for _, tier := range flattenPriceTiers(tiers) {
+				got = append(got, tier["up_to_inf"].(bool))
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected up_to_inf %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestResourceStripePriceRecurringChangeReplaces(t *testing.T) {
+	r := resourceStripePrice()
+	state := r.TestResourceData()
+	state.SetId("price_123")
+	state.Set("currency", "usd")
+	state.Set("product", "prod_123")
+	state.Set("unit_amount", 100)
+	state.Set("active", true)
+	state.Set("recurring", map[string]interface{}{"interval": "month", "usage_type": "licensed"})
+
+	cases := map[string]map[string]interface{}{
+		"interval":        {"interval": "year", "usage_type": "licensed"},
+		"usage_type":      {"interval": "month", "usage_type": "metered"},
+		"aggregate_usage": {"interval": "month", "usage_type": "licensed", "aggregate_usage": "max"},
+		"removed":         nil,
+	}
+
+	for name, recurring := range cases {
+		t.Run(name, func(t *testing.T) {
+			config := map[string]interface{}{
+				"currency":    "usd",
+				"product":     "prod_123",
+				"unit_amount": 100,
+			}
+			if recurring != nil {
+				config["recurring"] = recurring
+			}
+
+			diff, err := r.Diff(context.Background(), state.State(), terraform.NewResourceConfigRaw(config), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff == nil || !diff.RequiresNew() {
+				t.Errorf("expected a replacement, got %#v", diff)
+			}
+		})
+	}
+}
+
+func TestResourceStripePriceReadWithRestrictedKey(t *testing.T) {
+	requests := 0
+	m := testProviderMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Query().Get("expand[0]") != "" {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"error": {"type": "invalid_request_error", "message": "The provided key does not have the required permissions to expand currency_options."}}`)
+			return
+		}
+		fmt.Fprint(w, `{"id": "price_123", "object": "price", "active": true, "currency": "usd", "unit_amount": 1500, "billing_scheme": "tiered", "tiers_mode": "graduated"}`)
+	})
+
+	r := resourceStripePrice()
+	d := r.TestResourceData()
+	d.SetId("price_123")
+	d.Set("tier", []interface{}{
+		map[string]interface{}{"up_to": 10, "unit_amount": 100},
+		map[string]interface{}{"up_to_inf": true, "unit_amount": 50},
+	})
+
+	if diags := r.ReadContext(context.Background(), d, m); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if requests != 2 {
+		t.Errorf("expected the price to be read again without expansion, got %d requests", requests)
+	}
+	if got := d.Get("unit_amount").(int); got != 1500 {
+		t.Errorf("expected unit_amount 1500, got %d", got)
+	}
+	if got := d.Get("tier.#").(int); got != 2 {
+		t.Errorf("expected the tiers to be left as they were, got %d", got)
+	}
+}
+
+func TestResourceStripePriceCurrencyOptionTaxBehaviorRoundTrip(t *testing.T) {
+	m := testProviderMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			if err := r.ParseForm(); err != nil {
+				t.Fatal(err)
+			}
+			if got := r.PostForm.Get("currency_options[eur][tax_behavior]"); got != "exclusive" {
+				t.Errorf("expected the currency option tax behavior to be sent, got %q", got)
+			}
+		}
+		fmt.Fprint(w, `{"id": "price_123", "object": "price", "active": true, "currency": "usd", "unit_amount": 1500, "billing_scheme": "per_unit", "tax_behavior": "exclusive", "product": "prod_123", "currency_options": {
+			"usd": {"unit_amount": 1500, "tax_behavior": "exclusive"},
+			"eur": {"unit_amount": 1400, "tax_behavior": "exclusive"}
+		}}`)
+	})
+
+	config := map[string]interface{}{
+		"currency":       "usd",
+		"product":        "prod_123",
+		"unit_amount":    1500,
+		"billing_scheme": "per_unit",
+		"tax_behavior":   "exclusive",
+		"currency_options": []interface{}{
+			map[string]interface{}{"currency": "eur", "unit_amount": 1400, "tax_behavior": "exclusive"},
+		},
+	}
+
+	r := resourceStripePrice()
+	d := testResourceCreateData(t, r, config)
+
+	if diags := r.CreateContext(context.Background(), d, m); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !diff.Empty() {
+		t.Errorf("expected no diff, got %v", diff.Attributes)
+	}
+}
+
+func TestResourceStripePriceTrimmedNicknameAndEmptyMetadata(t *testing.T) {
+	cases := []struct {
+		name     string
+		nickname string
+		metadata map[string]interface{}
+		diff     bool
+	}{
+		{
+			name:     "trailing spaces and empty metadata value",
+			nickname: "Monthly  ",
+			metadata: map[string]interface{}{"plan": "pro", "note": ""},
+		},
+		{
+			name:     "leading spaces",
+			nickname: " Monthly",
+			metadata: map[string]interface{}{"plan": "pro"},
+		},
+		{
+			name:     "nickname changed",
+			nickname: "Yearly ",
+			metadata: map[string]interface{}{"plan": "pro"},
+			diff:     true,
+		},
+		{
+			name:     "metadata changed",
+			nickname: "Monthly",
+			metadata: map[string]interface{}{"plan": "pro", "note": "legacy"},
+			diff:     true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := resourceStripePrice()
+			d := r.TestResourceData()
+			d.SetId("price_123")
+			d.Set("currency", "usd")
+			d.Set("product", "prod_123")
+			d.Set("unit_amount", 1500)
+			d.Set("active", true)
+			d.Set("tax_behavior", "unspecified")
+			d.Set("transfer_lookup_key", false)
+			d.Set("treat_archived_as_missing", false)
+			// Stripe trims nicknames and drops metadata with empty values.
+			d.Set("nickname", "Monthly")
+			d.Set("metadata", map[string]interface{}{"plan": "pro"})
+
+			diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+				"currency":    "usd",
+				"product":     "prod_123",
+				"unit_amount": 1500,
+				"nickname":    tc.nickname,
+				"metadata":    tc.metadata,
+			}), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := diff != nil && !diff.Empty(); got != tc.diff {
+				t.Errorf("expected a diff: %t, got %v", tc.diff, diff)
+			}
+		})
+	}
+}
+
+func TestResourceStripePriceReadArchived(t *testing.T) {
+	m := testProviderMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "price_123", "object": "price", "active": false, "currency": "usd", "unit_amount": 1500}`)
+	})
+
+	cases := []struct {
+		name    string
+		active  bool
+		missing bool
+		removed bool
+	}{
+		{
+			name:   "reflected by default",
+			active: true,
+		},
+		{
+			name:    "treated as missing",
+			active:  true,
+			missing: true,
+			removed: true,
+		},
+		{
+			name:    "archived on purpose",
+			missing: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := resourceStripePrice()
+			d := r.TestResourceData()
+			d.SetId("price_123")
+			d.Set("active", tc.active)
+			d.Set("treat_archived_as_missing", tc.missing)
+
+			if diags := r.ReadContext(context.Background(), d, m); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			if removed := d.Id() == ""; removed != tc.removed {
+				t.Fatalf("expected the price to be removed from the state: %t, got ID %q", tc.removed, d.Id())
+			}
+			if !tc.removed && d.Get("active").(bool) {
+				t.Errorf("expected the price to be read as archived")
+			}
+		})
+	}
+}
+
+func TestResourceStripePriceRecurringRoundTrip(t *testing.T) {
+	m := testProviderMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "price_123", "object": "price", "active": true, "currency": "usd", "unit_amount": 1500, "billing_scheme": "per_unit", "tax_behavior": "unspecified", "product": "prod_123", "type": "recurring", "recurring": {"interval": "month", "interval_count": 3, "usage_type": "licensed"}}`)
+	})
+
+	config := map[string]interface{}{
+		"currency":    "usd",
+		"product":     "prod_123",
+		"unit_amount": 1500,
+		"recurring":   map[string]interface{}{"interval": "month", "interval_count": "3"},
+	}
+
+	r := resourceStripePrice()
+	d := testResourceCreateData(t, r, config)
+
+	if diags := r.CreateContext(context.Background(), d, m); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if got := d.Get("recurring.interval_count"); got != "3" {
+		t.Errorf("expected interval_count 3, got %v", got)
+	}
+
+	diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !diff.Empty() {
+		t.Errorf("expected no diff, got %v", diff.Attributes)
+	}
+}
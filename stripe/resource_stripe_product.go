@@ -2,6 +2,10 @@ package stripe
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -22,8 +26,9 @@ func resourceStripeProduct() *schema.Resource {
 		UpdateContext: resourceStripeProductUpdate,
 		DeleteContext: resourceStripeProductDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateCheckingLivemode(resourceStripeProductLivemode),
 		},
+		CustomizeDiff: resourceStripeProductCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"product_id": {
@@ -37,14 +42,21 @@ func resourceStripeProduct() *schema.Resource {
 				Required: true,
 			},
 			"type": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateEnum("good", "service"),
 			},
 			"active": {
 				Type:     schema.TypeBool,
 				Optional: true,
 				Default:  true,
 			},
+			"treat_archived_as_missing": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			"attributes": {
 				Type:     schema.TypeList,
 				Elem:     &schema.Schema{Type: schema.TypeString},
@@ -65,34 +77,209 @@ func resourceStripeProduct() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"tax_code": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			// Kept as a list, the first image is the one Stripe displays.
+			"images": {
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				MaxItems: 8,
+				Optional: true,
+			},
+			"url": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"shippable": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"package_dimensions": {
+				Type: schema.TypeList,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"height": {
+							Type:     schema.TypeFloat,
+							Required: true,
+						},
+						"length": {
+							Type:     schema.TypeFloat,
+							Required: true,
+						},
+						"weight": {
+							Type:     schema.TypeFloat,
+							Required: true,
+						},
+						"width": {
+							Type:     schema.TypeFloat,
+							Required: true,
+						},
+					},
+				},
+				MaxItems: 1,
+				Optional: true,
+			},
+			// Prices belong to a product, so the default one can only be set
+			// once the product exists.
+			"default_price": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"default_price_data"},
+			},
+			// Only used to create the product along with its first price.
+			"default_price_data": {
+				Type: schema.TypeList,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"currency": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"unit_amount": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ExactlyOneOf: []string{"default_price_data.0.unit_amount", "default_price_data.0.unit_amount_decimal"},
+						},
+						"unit_amount_decimal": {
+							Type:         schema.TypeFloat,
+							Optional:     true,
+							ExactlyOneOf: []string{"default_price_data.0.unit_amount", "default_price_data.0.unit_amount_decimal"},
+						},
+						"recurring": {
+							Type: schema.TypeMap,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Optional: true,
+						},
+						"tax_behavior": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateTaxBehavior,
+						},
+					},
+				},
+				MaxItems: 1,
+				Optional: true,
+			},
+			"archive_on_delete": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 		},
 	}
 }
 
+// resourceStripeProductCustomizeDiff rejects changes to default_price_data
+// once the product exists, Stripe only reads it on creation.
+func resourceStripeProductCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !d.HasChange("default_price_data") {
+		return nil
+	}
+
+	if len(d.Get("default_price_data").([]interface{})) > 0 {
+		return fmt.Errorf("default_price_data: can't be changed once the product is created, use a stripe_price and default_price instead")
+	}
+
+	return nil
+}
+
+func expandPackageDimensions(in []interface{}) *stripe.PackageDimensionsParams {
+	if len(in) == 0 || in[0] == nil {
+		return nil
+	}
+
+	dimensions := in[0].(map[string]interface{})
+	return &stripe.PackageDimensionsParams{
+		Height: stripe.Float64(dimensions["height"].(float64)),
+		Length: stripe.Float64(dimensions["length"].(float64)),
+		Weight: stripe.Float64(dimensions["weight"].(float64)),
+		Width:  stripe.Float64(dimensions["width"].(float64)),
+	}
+}
+
+func flattenPackageDimensions(in *stripe.PackageDimensions) []map[string]interface{} {
+	if in == nil {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{
+		{
+			"height": in.Height,
+			"length": in.Length,
+			"weight": in.Weight,
+			"width":  in.Width,
+		},
+	}
+}
+
+func expandProductDefaultPriceData(in []interface{}) (*stripe.ProductDefaultPriceDataParams, diag.Diagnostics) {
+	if len(in) == 0 || in[0] == nil {
+		return nil, nil
+	}
+
+	data := in[0].(map[string]interface{})
+	params := &stripe.ProductDefaultPriceDataParams{
+		Currency: stripe.String(data["currency"].(string)),
+	}
+
+	// Exactly one of them is configured, a zero amount is sent as unit_amount
+	// either way.
+	if unitAmountDecimal := data["unit_amount_decimal"].(float64); unitAmountDecimal > 0 {
+		params.UnitAmountDecimal = stripe.Float64(unitAmountDecimal)
+	} else {
+		params.UnitAmount = stripe.Int64(int64(data["unit_amount"].(int)))
+	}
+
+	recurring, err := expandStringMap(data["recurring"].(map[string]interface{}))
+	if err != nil {
+		return nil, diag.Errorf("default_price_data: recurring: %s", err)
+	}
+
+	if len(recurring) > 0 {
+		if recurring["interval"] == "" {
+			return nil, diag.Errorf("default_price_data: recurring: interval is required")
+		}
+		params.Recurring = &stripe.ProductDefaultPriceDataRecurringParams{
+			Interval: stripe.String(recurring["interval"]),
+		}
+
+		if intervalCount, ok := recurring["interval_count"]; ok {
+			intervalCountInt, err := strconv.ParseInt(intervalCount, 10, 64)
+			if err != nil {
+				return nil, diag.Errorf("default_price_data: interval_count must be a string, representing an int (e.g. \"52\")")
+			}
+			params.Recurring.IntervalCount = stripe.Int64(intervalCountInt)
+		}
+	}
+
+	if taxBehavior := data["tax_behavior"].(string); taxBehavior != "" {
+		params.TaxBehavior = stripe.String(taxBehavior)
+	}
+
+	return params, nil
+}
+
 func resourceStripeProductCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*client.API)
+	client := m.(*providerMeta).API
 	productName := d.Get("name").(string)
-	productType := d.Get("type").(string)
 	productStatementDescriptor := d.Get("statement_descriptor").(string)
 	productUnitLabel := d.Get("unit_label").(string)
 
-	var stripeProductType stripe.ProductType
-
-	switch productType {
-	case "good":
-		stripeProductType = stripe.ProductTypeGood
-	case "service":
-		stripeProductType = stripe.ProductTypeService
-	default:
-		return diag.Errorf("unknown type: %s", productType)
-	}
-
 	params := &stripe.ProductParams{
 		Name: stripe.String(productName),
-		Type: stripe.String(string(stripeProductType)),
 	}
 	params.Context = ctx
 
+	if productType, ok := d.GetOk("type"); ok {
+		params.Type = stripe.String(productType.(string))
+	}
+
 	if productID, ok := d.GetOk("product_id"); ok {
 		params.ID = stripe.String(productID.(string))
 	}
@@ -103,7 +290,11 @@ func resourceStripeProductCreate(ctx context.Context, d *schema.ResourceData, m
 
 	params.Attributes = expandAttributes(d)
 
-	params.Metadata = expandMetadata(d)
+	metadata, diags := expandMetadata(d)
+	if diags.HasError() {
+		return diags
+	}
+	params.Metadata = metadata
 
 	if productStatementDescriptor != "" {
 		params.StatementDescriptor = stripe.String(productStatementDescriptor)
@@ -113,6 +304,33 @@ func resourceStripeProductCreate(ctx context.Context, d *schema.ResourceData, m
 		params.UnitLabel = stripe.String(productUnitLabel)
 	}
 
+	if taxCode, ok := d.GetOk("tax_code"); ok {
+		params.TaxCode = stripe.String(taxCode.(string))
+	}
+
+	params.Images = expandStringList(d, "images")
+
+	if url, ok := d.GetOk("url"); ok {
+		params.URL = stripe.String(url.(string))
+	}
+
+	if shippable, ok := d.GetOkExists("shippable"); ok {
+		params.Shippable = stripe.Bool(shippable.(bool))
+	}
+
+	if packageDimensions, ok := d.GetOk("package_dimensions"); ok {
+		params.PackageDimensions = expandPackageDimensions(packageDimensions.([]interface{}))
+	}
+
+	if defaultPriceData, ok := d.GetOk("default_price_data"); ok {
+		defaultPriceDataParams, diags := expandProductDefaultPriceData(defaultPriceData.([]interface{}))
+		if diags.HasError() {
+			return diags
+		}
+		params.DefaultPriceData = defaultPriceDataParams
+	}
+
+	setIdempotencyKey(params, d)
 	product, err := client.Products.New(params)
 	if err != nil {
 		return diag.FromErr(err)
@@ -121,11 +339,34 @@ func resourceStripeProductCreate(ctx context.Context, d *schema.ResourceData, m
 	log.Printf("[INFO] Created Stripe product: %s", productName)
 	d.SetId(product.ID)
 
+	if defaultPrice, ok := d.GetOk("default_price"); ok {
+		params := &stripe.ProductParams{
+			DefaultPrice: stripe.String(defaultPrice.(string)),
+		}
+		params.Context = ctx
+
+		if _, err := client.Products.Update(d.Id(), params); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceStripeProductRead(ctx, d, m)
 }
 
+func resourceStripeProductLivemode(ctx context.Context, client *client.API, id string) (bool, error) {
+	params := &stripe.ProductParams{}
+	params.Context = ctx
+
+	product, err := client.Products.Get(id, params)
+	if err != nil {
+		return false, err
+	}
+
+	return product.Livemode, nil
+}
+
 func resourceStripeProductRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*client.API)
+	client := m.(*providerMeta).API
 
 	params := &stripe.ProductParams{}
 	params.Context = ctx
@@ -135,6 +376,14 @@ func resourceStripeProductRead(ctx context.Context, d *schema.ResourceData, m in
 		return diag.FromErr(err)
 	}
 
+	// Only an archival made outside of Terraform counts, prices and products
+	// configured as inactive are expected to be archived.
+	if !product.Active && d.Get("active").(bool) && d.Get("treat_archived_as_missing").(bool) {
+		log.Printf("[WARN] Product %s was archived, removing it from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
 	d.Set("product_id", product.ID)
 	d.Set("name", product.Name)
 	d.Set("type", product.Type)
@@ -143,12 +392,26 @@ func resourceStripeProductRead(ctx context.Context, d *schema.ResourceData, m in
 	d.Set("metadata", product.Metadata)
 	d.Set("statement_descriptor", product.StatementDescriptor)
 	d.Set("unit_label", product.UnitLabel)
+	if product.TaxCode != nil {
+		d.Set("tax_code", product.TaxCode.ID)
+	} else {
+		d.Set("tax_code", "")
+	}
+	d.Set("images", product.Images)
+	d.Set("url", product.URL)
+	d.Set("shippable", product.Shippable)
+	d.Set("package_dimensions", flattenPackageDimensions(product.PackageDimensions))
+	if product.DefaultPrice != nil {
+		d.Set("default_price", product.DefaultPrice.ID)
+	} else {
+		d.Set("default_price", "")
+	}
 
 	return nil
 }
 
 func resourceStripeProductUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*client.API)
+	client := m.(*providerMeta).API
 
 	params := &stripe.ProductParams{}
 	params.Context = ctx
@@ -170,7 +433,11 @@ func resourceStripeProductUpdate(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	if d.HasChange("metadata") {
-		params.Metadata = expandMetadata(d)
+		metadata, diags := expandMetadata(d)
+		if diags.HasError() {
+			return diags
+		}
+		params.Metadata = metadata
 	}
 
 	if d.HasChange("statement_descriptor") {
@@ -181,6 +448,38 @@ func resourceStripeProductUpdate(ctx context.Context, d *schema.ResourceData, m
 		params.UnitLabel = stripe.String(d.Get("unit_label").(string))
 	}
 
+	if d.HasChange("tax_code") {
+		params.TaxCode = stripe.String(d.Get("tax_code").(string))
+	}
+
+	if d.HasChange("images") {
+		if images := expandStringList(d, "images"); images != nil {
+			params.Images = images
+		} else {
+			params.AddExtra("images", "")
+		}
+	}
+
+	if d.HasChange("url") {
+		params.URL = stripe.String(d.Get("url").(string))
+	}
+
+	if d.HasChange("shippable") {
+		params.Shippable = stripe.Bool(d.Get("shippable").(bool))
+	}
+
+	if d.HasChange("package_dimensions") {
+		if packageDimensions := expandPackageDimensions(d.Get("package_dimensions").([]interface{})); packageDimensions != nil {
+			params.PackageDimensions = packageDimensions
+		} else {
+			params.AddExtra("package_dimensions", "")
+		}
+	}
+
+	if d.HasChange("default_price") {
+		params.DefaultPrice = stripe.String(d.Get("default_price").(string))
+	}
+
 	_, err := client.Products.Update(d.Id(), params)
 	if err != nil {
 		return diag.FromErr(err)
@@ -190,16 +489,52 @@ func resourceStripeProductUpdate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceStripeProductDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*client.API)
+	client := m.(*providerMeta).API
 
 	params := &stripe.ProductParams{}
 	params.Context = ctx
 
-	if _, err := client.Products.Del(d.Id(), params); err != nil {
+	_, err := client.Products.Del(d.Id(), params)
+	if err == nil {
+		d.SetId("")
+		return nil
+	}
+
+	// Products with prices can't be deleted, only archived.
+	if !isProductHasPricesError(err) || !d.Get("archive_on_delete").(bool) {
+		return diag.FromErr(err)
+	}
+	stripeErr := err.(*stripe.Error)
+
+	archiveParams := &stripe.ProductParams{
+		Active: stripe.Bool(false),
+	}
+	archiveParams.Context = ctx
+
+	product, err := client.Products.Update(d.Id(), archiveParams)
+	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	d.SetId("")
 
-	return nil
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  "Product archived instead of deleted",
+			Detail:   fmt.Sprintf("Stripe refused to delete product %s (%s), it was archived instead.", product.ID, stripeErr.Msg),
+		},
+	}
+}
+
+// isProductHasPricesError reports whether Stripe refused to delete a product
+// because prices were created for it, e.g. "This product cannot be deleted
+// because it has one or more user-created prices."
+func isProductHasPricesError(err error) bool {
+	stripeErr, ok := err.(*stripe.Error)
+	if !ok || stripeErr.HTTPStatusCode != http.StatusBadRequest {
+		return false
+	}
+
+	return strings.Contains(strings.ToLower(stripeErr.Msg), "prices")
 }
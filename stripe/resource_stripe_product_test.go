@@ -0,0 +1,234 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceStripeProductDefaultPriceDataAmounts(t *testing.T) {
+	cases := []struct {
+		name             string
+		defaultPriceData []interface{}
+		err              string
+	}{
+		{
+			name: "no default price",
+		},
+		{
+			name:             "unit amount",
+			defaultPriceData: []interface{}{map[string]interface{}{"currency": "usd", "unit_amount": 100}},
+		},
+		{
+			name:             "free",
+			defaultPriceData: []interface{}{map[string]interface{}{"currency": "usd", "unit_amount": 0}},
+		},
+		{
+			name:             "unit amount decimal",
+			defaultPriceData: []interface{}{map[string]interface{}{"currency": "usd", "unit_amount_decimal": 0.5}},
+		},
+		{
+			name:             "no amount",
+			defaultPriceData: []interface{}{map[string]interface{}{"currency": "usd"}},
+			err:              "one of",
+		},
+		{
+			name:             "both amounts",
+			defaultPriceData: []interface{}{map[string]interface{}{"currency": "usd", "unit_amount": 100, "unit_amount_decimal": 0.5}},
+			err:              "only one of",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			raw := map[string]interface{}{"name": "Seat"}
+			if tc.defaultPriceData != nil {
+				raw["default_price_data"] = tc.defaultPriceData
+			}
+
+			diags := resourceStripeProduct().Validate(terraform.NewResourceConfigRaw(raw))
+			if tc.err == "" && diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if tc.err != "" {
+				if !diags.HasError() {
+					t.Fatalf("expected error containing %q", tc.err)
+				}
+				if !strings.Contains(diags[0].Detail, tc.err) {
+					t.Fatalf("expected error containing %q, got %q", tc.err, diags[0].Detail)
+				}
+			}
+		})
+	}
+}
+
+func TestResourceStripeProductDefaultPriceDataExpand(t *testing.T) {
+	params, diags := expandProductDefaultPriceData([]interface{}{
+		map[string]interface{}{"currency": "usd", "unit_amount": 0, "unit_amount_decimal": 0.0, "recurring": map[string]interface{}{}, "tax_behavior": ""},
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if params.UnitAmount == nil || *params.UnitAmount != 0 || params.UnitAmountDecimal != nil {
+		t.Errorf("expected a free price, got %#v", params)
+	}
+
+	params, _ = expandProductDefaultPriceData([]interface{}{
+		map[string]interface{}{"currency": "usd", "unit_amount": 0, "unit_amount_decimal": 0.5, "recurring": map[string]interface{}{}, "tax_behavior": ""},
+	})
+	if params.UnitAmount != nil || params.UnitAmountDecimal == nil || *params.UnitAmountDecimal != 0.5 {
+		t.Errorf("expected a decimal amount, got %#v", params)
+	}
+}
+
+func TestResourceStripeProductDefaultPriceDataExpandRecurring(t *testing.T) {
+	cases := []struct {
+		name      string
+		recurring map[string]interface{}
+		err       string
+	}{
+		{
+			name:      "interval",
+			recurring: map[string]interface{}{"interval": "month"},
+		},
+		{
+			name:      "interval and count",
+			recurring: map[string]interface{}{"interval": "month", "interval_count": "3"},
+		},
+		{
+			name:      "count without interval",
+			recurring: map[string]interface{}{"interval_count": "3"},
+			err:       "default_price_data: recurring: interval is required",
+		},
+		{
+			name:      "empty interval",
+			recurring: map[string]interface{}{"interval": ""},
+			err:       "default_price_data: recurring: interval is required",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			params, diags := expandProductDefaultPriceData([]interface{}{
+				map[string]interface{}{"currency": "usd", "unit_amount": 100, "unit_amount_decimal": 0.0, "recurring": tc.recurring, "tax_behavior": ""},
+			})
+
+			if tc.err != "" {
+				if !diags.HasError() || diags[0].Summary != tc.err {
+					t.Fatalf("expected error %q, got %v", tc.err, diags)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if params.Recurring == nil || *params.Recurring.Interval != "month" {
+				t.Errorf("expected a monthly price, got %#v", params.Recurring)
+			}
+		})
+	}
+}
+
+func TestResourceStripeProductCustomizeDiffDefaultPriceData(t *testing.T) {
+	defaultPriceData := func(amount int) []interface{} {
+		return []interface{}{map[string]interface{}{"currency": "usd", "unit_amount": amount}}
+	}
+
+	cases := []struct {
+		name             string
+		defaultPriceData []interface{}
+		err              string
+	}{
+		{
+			name:             "unchanged",
+			defaultPriceData: defaultPriceData(100),
+		},
+		{
+			name: "removed",
+		},
+		{
+			name:             "changed",
+			defaultPriceData: defaultPriceData(200),
+			err:              "default_price_data: can't be changed",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := resourceStripeProduct()
+			d := r.TestResourceData()
+			d.SetId("prod_123")
+			d.Set("name", "Seat")
+			d.Set("active", true)
+			d.Set("default_price", "price_123")
+			d.Set("default_price_data", defaultPriceData(100))
+
+			raw := map[string]interface{}{"name": "Seat"}
+			if tc.defaultPriceData != nil {
+				raw["default_price_data"] = tc.defaultPriceData
+			}
+
+			_, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(raw), nil)
+			if tc.err == "" && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if tc.err != "" && (err == nil || !strings.Contains(err.Error(), tc.err)) {
+				t.Fatalf("expected error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestResourceStripeProductDeleteArchive(t *testing.T) {
+	cases := []struct {
+		name      string
+		deleteErr string
+		archived  bool
+	}{
+		{
+			name:      "product with prices",
+			deleteErr: "This product cannot be deleted because it has one or more user-created prices.",
+			archived:  true,
+		},
+		{
+			name:      "other error",
+			deleteErr: "Invalid request, please retry later.",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			archived := false
+			m := testProviderMeta(t, func(w http.ResponseWriter, r *http.Request) {
+				switch r.Method {
+				case http.MethodDelete:
+					w.WriteHeader(http.StatusBadRequest)
+					fmt.Fprintf(w, `{"error": {"type": "invalid_request_error", "message": %q}}`, tc.deleteErr)
+				case http.MethodPost:
+					archived = true
+					fmt.Fprint(w, `{"id": "prod_123", "object": "product", "active": false}`)
+				}
+			})
+
+			r := resourceStripeProduct()
+			d := r.TestResourceData()
+			d.SetId("prod_123")
+			d.Set("archive_on_delete", true)
+
+			diags := r.DeleteContext(context.Background(), d, m)
+			if archived != tc.archived {
+				t.Fatalf("expected archived to be %t", tc.archived)
+			}
+			if tc.archived && (diags.HasError() || d.Id() != "") {
+				t.Errorf("expected the product to be archived and removed, got %v", diags)
+			}
+			if !tc.archived && !diags.HasError() {
+				t.Errorf("expected the delete error to be returned")
+			}
+		})
+	}
+}
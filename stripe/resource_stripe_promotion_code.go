@@ -0,0 +1,287 @@
+package stripe
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	stripe "github.com/stripe/stripe-go/v72"
+	"github.com/stripe/stripe-go/v72/client"
+)
+
+func resourceStripePromotionCode() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceStripePromotionCodeCreate,
+		ReadContext:   resourceStripePromotionCodeRead,
+		UpdateContext: resourceStripePromotionCodeUpdate,
+		DeleteContext: resourceStripePromotionCodeDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateCheckingLivemode(resourceStripePromotionCodeLivemode),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"coupon": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"code": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true, // generated by Stripe when left blank
+				ForceNew: true,
+			},
+			"active": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"customer": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"expires_at": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ValidateFunc:     validation.IsRFC3339Time,
+				DiffSuppressFunc: suppressEquivalentTime,
+			},
+			"max_redemptions": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+			},
+			"metadata": {
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional: true,
+			},
+			"restrictions": {
+				Type: schema.TypeList,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"first_time_transaction": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							ForceNew: true,
+						},
+						"minimum_amount": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							ForceNew: true,
+						},
+						"minimum_amount_currency": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+					},
+				},
+				MaxItems: 1,
+				Optional: true,
+				ForceNew: true,
+			},
+			// Computed
+			"times_redeemed": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"created": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"livemode": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func expandPromotionCodeRestrictions(in []interface{}) *stripe.PromotionCodeRestrictionsParams {
+	if len(in) == 0 || in[0] == nil {
+		return nil
+	}
+
+	restrictions := in[0].(map[string]interface{})
+	out := &stripe.PromotionCodeRestrictionsParams{}
+
+	if firstTimeTransaction, ok := restrictions["first_time_transaction"].(bool); ok && firstTimeTransaction {
+		out.FirstTimeTransaction = stripe.Bool(true)
+	}
+
+	if minimumAmount, ok := restrictions["minimum_amount"].(int); ok && minimumAmount > 0 {
+		out.MinimumAmount = stripe.Int64(int64(minimumAmount))
+	}
+
+	if currency, ok := restrictions["minimum_amount_currency"].(string); ok && currency != "" {
+		out.MinimumAmountCurrency = stripe.String(currency)
+	}
+
+	return out
+}
+
+func flattenPromotionCodeRestrictions(in *stripe.PromotionCodeRestrictions) []map[string]interface{} {
+	if in == nil || (!in.FirstTimeTransaction && in.MinimumAmount == 0) {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{
+		{
+			"first_time_transaction":  in.FirstTimeTransaction,
+			"minimum_amount":          in.MinimumAmount,
+			"minimum_amount_currency": string(in.MinimumAmountCurrency),
+		},
+	}
+}
+
+func resourceStripePromotionCodeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+	coupon := d.Get("coupon").(string)
+
+	params := &stripe.PromotionCodeParams{
+		Coupon: stripe.String(coupon),
+		Active: stripe.Bool(d.Get("active").(bool)),
+	}
+	params.Context = ctx
+
+	if code, ok := d.GetOk("code"); ok {
+		params.Code = stripe.String(code.(string))
+	}
+
+	if customer, ok := d.GetOk("customer"); ok {
+		params.Customer = stripe.String(customer.(string))
+	}
+
+	if expiresAt, ok := d.GetOk("expires_at"); ok {
+		expiresAtTime, err := time.Parse(time.RFC3339, expiresAt.(string))
+		if err != nil {
+			return diag.Errorf("can't convert time \"%s\" to time.  Please check if it's RFC3339-compliant", expiresAt)
+		}
+
+		params.ExpiresAt = stripe.Int64(expiresAtTime.Unix())
+	}
+
+	if maxRedemptions, ok := d.GetOk("max_redemptions"); ok {
+		params.MaxRedemptions = stripe.Int64(int64(maxRedemptions.(int)))
+	}
+
+	if restrictions, ok := d.GetOk("restrictions"); ok {
+		params.Restrictions = expandPromotionCodeRestrictions(restrictions.([]interface{}))
+	}
+
+	metadata, diags := expandMetadata(d)
+	if diags.HasError() {
+		return diags
+	}
+	params.Metadata = metadata
+
+	setIdempotencyKey(params, d)
+	promotionCode, err := client.PromotionCodes.New(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Create promotion code: %s (%s)", promotionCode.Code, coupon)
+	d.SetId(promotionCode.ID)
+
+	return resourceStripePromotionCodeRead(ctx, d, m)
+}
+
+func resourceStripePromotionCodeLivemode(ctx context.Context, client *client.API, id string) (bool, error) {
+	params := &stripe.PromotionCodeParams{}
+	params.Context = ctx
+
+	promotionCode, err := client.PromotionCodes.Get(id, params)
+	if err != nil {
+		return false, err
+	}
+
+	return promotionCode.Livemode, nil
+}
+
+func resourceStripePromotionCodeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.PromotionCodeParams{}
+	params.Context = ctx
+
+	promotionCode, err := client.PromotionCodes.Get(d.Id(), params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if promotionCode.Coupon != nil {
+		d.Set("coupon", promotionCode.Coupon.ID)
+	}
+	d.Set("code", promotionCode.Code)
+	d.Set("active", promotionCode.Active)
+	if promotionCode.Customer != nil {
+		d.Set("customer", promotionCode.Customer.ID)
+	} else {
+		d.Set("customer", "")
+	}
+	if promotionCode.ExpiresAt > 0 {
+		d.Set("expires_at", time.Unix(promotionCode.ExpiresAt, 0).UTC().Format(time.RFC3339))
+	} else {
+		d.Set("expires_at", "")
+	}
+	d.Set("max_redemptions", promotionCode.MaxRedemptions)
+	d.Set("metadata", promotionCode.Metadata)
+	d.Set("restrictions", flattenPromotionCodeRestrictions(promotionCode.Restrictions))
+	d.Set("times_redeemed", promotionCode.TimesRedeemed)
+	d.Set("created", promotionCode.Created)
+	d.Set("livemode", promotionCode.Livemode)
+
+	return nil
+}
+
+func resourceStripePromotionCodeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.PromotionCodeParams{}
+	params.Context = ctx
+
+	if d.HasChange("active") {
+		params.Active = stripe.Bool(d.Get("active").(bool))
+	}
+
+	if d.HasChange("metadata") {
+		metadata, diags := expandMetadata(d)
+		if diags.HasError() {
+			return diags
+		}
+		params.Metadata = metadata
+	}
+
+	if _, err := client.PromotionCodes.Update(d.Id(), params); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStripePromotionCodeRead(ctx, d, m)
+}
+
+func resourceStripePromotionCodeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	// Stripe doesn't allow deleting promotion codes, deactivate it instead.
+	params := &stripe.PromotionCodeParams{
+		Active: stripe.Bool(false),
+	}
+	params.Context = ctx
+
+	if _, err := client.PromotionCodes.Update(d.Id(), params); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
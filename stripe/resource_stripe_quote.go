@@ -0,0 +1,387 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	stripe "github.com/stripe/stripe-go/v72"
+	"github.com/stripe/stripe-go/v72/client"
+)
+
+func resourceStripeQuote() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceStripeQuoteCreate,
+		ReadContext:   resourceStripeQuoteRead,
+		UpdateContext: resourceStripeQuoteUpdate,
+		DeleteContext: resourceStripeQuoteDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateCheckingLivemode(resourceStripeQuoteLivemode),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"customer": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"line_items": {
+				Type: schema.TypeList,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"price": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"quantity": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Computed: true,
+						},
+					},
+				},
+				MinItems: 1,
+				Required: true,
+			},
+			// Unix timestamp, Stripe defaults to 30 days after creation.
+			"expires_at": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"collection_method": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateEnum("charge_automatically", "send_invoice"),
+			},
+			"discounts": {
+				Type: schema.TypeList,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"coupon": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+				Optional: true,
+			},
+			"default_tax_rates": {
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Optional: true,
+			},
+			"metadata": {
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional: true,
+			},
+			// Finalized quotes can't go back to draft, so it only matters for
+			// drafts.
+			"finalize": {
+				Type:             schema.TypeBool,
+				Optional:         true,
+				Default:          false,
+				DiffSuppressFunc: suppressFinalizeUnlessDraft,
+			},
+			// Computed
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"number": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"amount_total": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"livemode": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// expandQuoteLineItems sends the whole list: Stripe keeps the line items
+// passed with their ID and removes the ones left out.
+func expandQuoteLineItems(in []interface{}) []*stripe.QuoteLineItemParams {
+	out := make([]*stripe.QuoteLineItemParams, 0, len(in))
+	for _, v := range in {
+		item := v.(map[string]interface{})
+		params := &stripe.QuoteLineItemParams{
+			Price: stripe.String(item["price"].(string)),
+		}
+
+		// Line items keep the ID Stripe assigned them, new ones don't have
+		// any yet.
+		if id := item["id"].(string); id != "" {
+			params.ID = stripe.String(id)
+		}
+
+		if quantity := item["quantity"].(int); quantity > 0 {
+			params.Quantity = stripe.Int64(int64(quantity))
+		}
+
+		out = append(out, params)
+	}
+
+	return out
+}
+
+func expandQuoteDiscounts(in []interface{}) []*stripe.QuoteDiscountParams {
+	out := make([]*stripe.QuoteDiscountParams, 0, len(in))
+	for _, v := range in {
+		out = append(out, &stripe.QuoteDiscountParams{
+			Coupon: stripe.String(v.(map[string]interface{})["coupon"].(string)),
+		})
+	}
+
+	return out
+}
+
+func resourceStripeQuoteCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+	customer := d.Get("customer").(string)
+
+	params := &stripe.QuoteParams{
+		Customer:  stripe.String(customer),
+		LineItems: expandQuoteLineItems(d.Get("line_items").([]interface{})),
+	}
+	params.Context = ctx
+
+	if expiresAt, ok := d.GetOk("expires_at"); ok {
+		params.ExpiresAt = stripe.Int64(int64(expiresAt.(int)))
+	}
+
+	if collectionMethod, ok := d.GetOk("collection_method"); ok {
+		params.CollectionMethod = stripe.String(collectionMethod.(string))
+	}
+
+	if discounts, ok := d.GetOk("discounts"); ok {
+		params.Discounts = expandQuoteDiscounts(discounts.([]interface{}))
+	}
+
+	params.DefaultTaxRates = expandStringList(d, "default_tax_rates")
+
+	metadata, diags := expandMetadata(d)
+	if diags.HasError() {
+		return diags
+	}
+	params.Metadata = metadata
+
+	setIdempotencyKey(params, d)
+	quote, err := client.Quotes.New(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Create quote: %s (%s)", quote.ID, customer)
+	d.SetId(quote.ID)
+
+	if d.Get("finalize").(bool) {
+		if err := finalizeQuote(ctx, client, quote.ID); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceStripeQuoteRead(ctx, d, m)
+}
+
+func finalizeQuote(ctx context.Context, client *client.API, id string) error {
+	params := &stripe.QuoteFinalizeQuoteParams{}
+	params.Context = ctx
+
+	quote, err := client.Quotes.FinalizeQuote(id, params)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Finalized quote: %s (%s)", quote.ID, quote.Number)
+	return nil
+}
+
+func resourceStripeQuoteLivemode(ctx context.Context, client *client.API, id string) (bool, error) {
+	params := &stripe.QuoteParams{}
+	params.Context = ctx
+
+	quote, err := client.Quotes.Get(id, params)
+	if err != nil {
+		return false, err
+	}
+
+	return quote.Livemode, nil
+}
+
+func resourceStripeQuoteRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.QuoteParams{}
+	params.Context = ctx
+
+	quote, err := client.Quotes.Get(d.Id(), params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	lineItems, err := listQuoteLineItems(ctx, client, quote.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if quote.Customer != nil {
+		d.Set("customer", quote.Customer.ID)
+	}
+	d.Set("line_items", flattenQuoteLineItems(lineItems))
+	d.Set("expires_at", quote.ExpiresAt)
+	d.Set("collection_method", quote.CollectionMethod)
+	discounts := make([]map[string]interface{}, 0, len(quote.Discounts))
+	for _, discount := range quote.Discounts {
+		if discount.Coupon != nil {
+			discounts = append(discounts, map[string]interface{}{
+				"coupon": discount.Coupon.ID,
+			})
+		}
+	}
+	d.Set("discounts", discounts)
+	defaultTaxRates := make([]string, len(quote.DefaultTaxRates))
+	for i, taxRate := range quote.DefaultTaxRates {
+		defaultTaxRates[i] = taxRate.ID
+	}
+	d.Set("default_tax_rates", defaultTaxRates)
+	d.Set("metadata", quote.Metadata)
+	d.Set("status", quote.Status)
+	d.Set("number", quote.Number)
+	d.Set("amount_total", quote.AmountTotal)
+	d.Set("livemode", quote.Livemode)
+
+	return nil
+}
+
+func listQuoteLineItems(ctx context.Context, client *client.API, quote string) ([]*stripe.LineItem, error) {
+	params := &stripe.QuoteListLineItemsParams{
+		Quote: stripe.String(quote),
+	}
+	params.Context = ctx
+
+	items, err := listAll[*stripe.LineItem](ctx, client.Quotes.ListLineItems(params), 0, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list the line items of quote %s: %s", quote, err)
+	}
+
+	return items, nil
+}
+
+func flattenQuoteLineItems(in []*stripe.LineItem) []map[string]interface{} {
+	out := make([]map[string]interface{}, 0, len(in))
+	for _, item := range in {
+		price := ""
+		if item.Price != nil {
+			price = item.Price.ID
+		}
+
+		out = append(out, map[string]interface{}{
+			"id":       item.ID,
+			"price":    price,
+			"quantity": item.Quantity,
+		})
+	}
+
+	return out
+}
+
+func resourceStripeQuoteUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.QuoteParams{}
+	params.Context = ctx
+
+	if d.HasChange("line_items") {
+		params.LineItems = expandQuoteLineItems(d.Get("line_items").([]interface{}))
+	}
+
+	if d.HasChange("expires_at") {
+		params.ExpiresAt = stripe.Int64(int64(d.Get("expires_at").(int)))
+	}
+
+	if d.HasChange("collection_method") {
+		params.CollectionMethod = stripe.String(d.Get("collection_method").(string))
+	}
+
+	if d.HasChange("discounts") {
+		if discounts, ok := d.GetOk("discounts"); ok {
+			params.Discounts = expandQuoteDiscounts(discounts.([]interface{}))
+		} else {
+			params.AddExtra("discounts", "")
+		}
+	}
+
+	if d.HasChange("default_tax_rates") {
+		if defaultTaxRates := expandStringList(d, "default_tax_rates"); defaultTaxRates != nil {
+			params.DefaultTaxRates = defaultTaxRates
+		} else {
+			params.AddExtra("default_tax_rates", "")
+		}
+	}
+
+	if d.HasChange("metadata") {
+		metadata, diags := expandMetadata(d)
+		if diags.HasError() {
+			return diags
+		}
+		params.Metadata = metadata
+	}
+
+	if _, err := client.Quotes.Update(d.Id(), params); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChange("finalize") && d.Get("finalize").(bool) && d.Get("status").(string) == string(stripe.QuoteStatusDraft) {
+		if err := finalizeQuote(ctx, client, d.Id()); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceStripeQuoteRead(ctx, d, m)
+}
+
+func resourceStripeQuoteDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.QuoteParams{}
+	params.Context = ctx
+
+	quote, err := client.Quotes.Get(d.Id(), params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Quotes can't be deleted, drafts and open ones are canceled instead and
+	// accepted ones are left to their subscription or invoice.
+	switch quote.Status {
+	case stripe.QuoteStatusDraft, stripe.QuoteStatusOpen:
+		cancelParams := &stripe.QuoteCancelParams{}
+		cancelParams.Context = ctx
+
+		if _, err := client.Quotes.Cancel(d.Id(), cancelParams); err != nil {
+			return diag.FromErr(err)
+		}
+	default:
+		log.Printf("[INFO] Quote %s is %s, removing it from state only", d.Id(), quote.Status)
+	}
+
+	d.SetId("")
+
+	return nil
+}
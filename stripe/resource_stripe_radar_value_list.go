@@ -0,0 +1,173 @@
+package stripe
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	stripe "github.com/stripe/stripe-go/v72"
+	"github.com/stripe/stripe-go/v72/client"
+)
+
+func resourceStripeRadarValueList() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceStripeRadarValueListCreate,
+		ReadContext:   resourceStripeRadarValueListRead,
+		UpdateContext: resourceStripeRadarValueListUpdate,
+		DeleteContext: resourceStripeRadarValueListDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateCheckingLivemode(resourceStripeRadarValueListLivemode),
+		},
+
+		Schema: map[string]*schema.Schema{
+			// Name used in Radar rules, e.g. @blocked_emails.
+			"alias": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"item_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "string",
+				ValidateFunc: validateEnum(
+					"card_bin",
+					"card_fingerprint",
+					"case_sensitive_string",
+					"country",
+					"customer_id",
+					"email",
+					"ip_address",
+					"string",
+				),
+			},
+			"metadata": {
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional: true,
+			},
+			// Computed
+			"created": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"livemode": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceStripeRadarValueListCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+	alias := d.Get("alias").(string)
+
+	params := &stripe.RadarValueListParams{
+		Alias:    stripe.String(alias),
+		Name:     stripe.String(d.Get("name").(string)),
+		ItemType: stripe.String(d.Get("item_type").(string)),
+	}
+	params.Context = ctx
+
+	metadata, diags := expandMetadata(d)
+	if diags.HasError() {
+		return diags
+	}
+	params.Metadata = metadata
+
+	setIdempotencyKey(params, d)
+	valueList, err := client.RadarValueLists.New(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Create Radar value list: %s (%s)", valueList.ID, alias)
+	d.SetId(valueList.ID)
+
+	return resourceStripeRadarValueListRead(ctx, d, m)
+}
+
+func resourceStripeRadarValueListLivemode(ctx context.Context, client *client.API, id string) (bool, error) {
+	params := &stripe.RadarValueListParams{}
+	params.Context = ctx
+
+	valueList, err := client.RadarValueLists.Get(id, params)
+	if err != nil {
+		return false, err
+	}
+
+	return valueList.Livemode, nil
+}
+
+func resourceStripeRadarValueListRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.RadarValueListParams{}
+	params.Context = ctx
+
+	valueList, err := client.RadarValueLists.Get(d.Id(), params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("alias", valueList.Alias)
+	d.Set("name", valueList.Name)
+	d.Set("item_type", valueList.ItemType)
+	d.Set("metadata", valueList.Metadata)
+	d.Set("created", valueList.Created)
+	d.Set("livemode", valueList.Livemode)
+
+	return nil
+}
+
+func resourceStripeRadarValueListUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.RadarValueListParams{}
+	params.Context = ctx
+
+	if d.HasChange("alias") {
+		params.Alias = stripe.String(d.Get("alias").(string))
+	}
+
+	if d.HasChange("name") {
+		params.Name = stripe.String(d.Get("name").(string))
+	}
+
+	if d.HasChange("metadata") {
+		metadata, diags := expandMetadata(d)
+		if diags.HasError() {
+			return diags
+		}
+		params.Metadata = metadata
+	}
+
+	if _, err := client.RadarValueLists.Update(d.Id(), params); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStripeRadarValueListRead(ctx, d, m)
+}
+
+func resourceStripeRadarValueListDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.RadarValueListParams{}
+	params.Context = ctx
+
+	if _, err := client.RadarValueLists.Del(d.Id(), params); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package stripe
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	stripe "github.com/stripe/stripe-go/v72"
+	"github.com/stripe/stripe-go/v72/client"
+)
+
+// Stripe doesn't allow updating value list items, every change recreates them.
+func resourceStripeRadarValueListItem() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceStripeRadarValueListItemCreate,
+		ReadContext:   resourceStripeRadarValueListItemRead,
+		DeleteContext: resourceStripeRadarValueListItemDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateCheckingLivemode(resourceStripeRadarValueListItemLivemode),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"value_list": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"value": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			// Computed
+			"created": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"livemode": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceStripeRadarValueListItemCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+	valueList := d.Get("value_list").(string)
+
+	params := &stripe.RadarValueListItemParams{
+		RadarValueList: stripe.String(valueList),
+		Value:          stripe.String(d.Get("value").(string)),
+	}
+	params.Context = ctx
+
+	setIdempotencyKey(params, d)
+	item, err := client.RadarValueListItems.New(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Create Radar value list item: %s (%s)", item.ID, valueList)
+	d.SetId(item.ID)
+
+	return resourceStripeRadarValueListItemRead(ctx, d, m)
+}
+
+func resourceStripeRadarValueListItemLivemode(ctx context.Context, client *client.API, id string) (bool, error) {
+	params := &stripe.RadarValueListItemParams{}
+	params.Context = ctx
+
+	item, err := client.RadarValueListItems.Get(id, params)
+	if err != nil {
+		return false, err
+	}
+
+	return item.Livemode, nil
+}
+
+func resourceStripeRadarValueListItemRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.RadarValueListItemParams{}
+	params.Context = ctx
+
+	item, err := client.RadarValueListItems.Get(d.Id(), params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("value_list", item.RadarValueList)
+	d.Set("value", item.Value)
+	d.Set("created", item.Created)
+	d.Set("livemode", item.Livemode)
+
+	return nil
+}
+
+func resourceStripeRadarValueListItemDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.RadarValueListItemParams{}
+	params.Context = ctx
+
+	if _, err := client.RadarValueListItems.Del(d.Id(), params); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
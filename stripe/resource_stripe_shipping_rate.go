@@ -0,0 +1,333 @@
+package stripe
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	stripe "github.com/stripe/stripe-go/v72"
+	"github.com/stripe/stripe-go/v72/client"
+)
+
+func resourceStripeShippingRate() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceStripeShippingRateCreate,
+		ReadContext:   resourceStripeShippingRateRead,
+		UpdateContext: resourceStripeShippingRateUpdate,
+		DeleteContext: resourceStripeShippingRateDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateCheckingLivemode(resourceStripeShippingRateLivemode),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"display_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "fixed_amount",
+				ForceNew:     true,
+				ValidateFunc: validateEnum("fixed_amount"),
+			},
+			"fixed_amount": {
+				Type: schema.TypeList,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"amount": {
+							Type:     schema.TypeInt,
+							Required: true,
+							ForceNew: true,
+						},
+						"currency": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+					},
+				},
+				MinItems: 1,
+				MaxItems: 1,
+				Required: true,
+				ForceNew: true,
+			},
+			"tax_behavior": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateTaxBehavior,
+			},
+			"tax_code": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"delivery_estimate": {
+				Type: schema.TypeList,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"minimum": deliveryEstimateBoundSchema(),
+						"maximum": deliveryEstimateBoundSchema(),
+					},
+				},
+				MaxItems: 1,
+				Optional: true,
+				ForceNew: true,
+			},
+			"active": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"metadata": {
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional: true,
+			},
+			// Computed
+			"created": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"livemode": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func deliveryEstimateBoundSchema() *schema.Schema {
+	return &schema.Schema{
+		Type: schema.TypeList,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"unit": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ForceNew:     true,
+					ValidateFunc: validateEnum("hour", "business_day", "day", "week", "month"),
+				},
+				"value": {
+					Type:     schema.TypeInt,
+					Required: true,
+					ForceNew: true,
+				},
+			},
+		},
+		MaxItems: 1,
+		Optional: true,
+		ForceNew: true,
+	}
+}
+
+func expandShippingRateDeliveryEstimate(in []interface{}) *stripe.ShippingRateDeliveryEstimateParams {
+	if len(in) == 0 || in[0] == nil {
+		return nil
+	}
+
+	estimate := in[0].(map[string]interface{})
+	out := &stripe.ShippingRateDeliveryEstimateParams{}
+
+	if minimum, ok := estimate["minimum"].([]interface{}); ok && len(minimum) > 0 && minimum[0] != nil {
+		bound := minimum[0].(map[string]interface{})
+		out.Minimum = &stripe.ShippingRateDeliveryEstimateMinimumParams{
+			Unit:  stripe.String(bound["unit"].(string)),
+			Value: stripe.Int64(int64(bound["value"].(int))),
+		}
+	}
+
+	if maximum, ok := estimate["maximum"].([]interface{}); ok && len(maximum) > 0 && maximum[0] != nil {
+		bound := maximum[0].(map[string]interface{})
+		out.Maximum = &stripe.ShippingRateDeliveryEstimateMaximumParams{
+			Unit:  stripe.String(bound["unit"].(string)),
+			Value: stripe.Int64(int64(bound["value"].(int))),
+		}
+	}
+
+	return out
+}
+
+func flattenShippingRateDeliveryEstimate(in *stripe.ShippingRateDeliveryEstimate) []map[string]interface{} {
+	if in == nil || (in.Minimum == nil && in.Maximum == nil) {
+		return []map[string]interface{}{}
+	}
+
+	out := map[string]interface{}{}
+	if in.Minimum != nil {
+		out["minimum"] = []map[string]interface{}{
+			{
+				"unit":  string(in.Minimum.Unit),
+				"value": in.Minimum.Value,
+			},
+		}
+	}
+	if in.Maximum != nil {
+		out["maximum"] = []map[string]interface{}{
+			{
+				"unit":  string(in.Maximum.Unit),
+				"value": in.Maximum.Value,
+			},
+		}
+	}
+
+	return []map[string]interface{}{out}
+}
+
+func resourceStripeShippingRateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+	displayName := d.Get("display_name").(string)
+	fixedAmount := d.Get("fixed_amount").([]interface{})[0].(map[string]interface{})
+
+	params := &stripe.ShippingRateParams{
+		DisplayName: stripe.String(displayName),
+		Type:        stripe.String(d.Get("type").(string)),
+		FixedAmount: &stripe.ShippingRateFixedAmountParams{
+			Amount:   stripe.Int64(int64(fixedAmount["amount"].(int))),
+			Currency: stripe.String(fixedAmount["currency"].(string)),
+		},
+	}
+	params.Context = ctx
+
+	if taxBehavior, ok := d.GetOk("tax_behavior"); ok {
+		params.TaxBehavior = stripe.String(taxBehavior.(string))
+	}
+
+	if taxCode, ok := d.GetOk("tax_code"); ok {
+		params.TaxCode = stripe.String(taxCode.(string))
+	}
+
+	if deliveryEstimate, ok := d.GetOk("delivery_estimate"); ok {
+		params.DeliveryEstimate = expandShippingRateDeliveryEstimate(deliveryEstimate.([]interface{}))
+	}
+
+	metadata, diags := expandMetadata(d)
+	if diags.HasError() {
+		return diags
+	}
+	params.Metadata = metadata
+
+	setIdempotencyKey(params, d)
+	shippingRate, err := client.ShippingRates.New(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Create shipping rate: %s (%s)", shippingRate.ID, displayName)
+	d.SetId(shippingRate.ID)
+
+	// Shipping rates are always created active.
+	if !d.Get("active").(bool) {
+		archiveParams := &stripe.ShippingRateParams{
+			Active: stripe.Bool(false),
+		}
+		archiveParams.Context = ctx
+
+		if _, err := client.ShippingRates.Update(shippingRate.ID, archiveParams); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceStripeShippingRateRead(ctx, d, m)
+}
+
+func resourceStripeShippingRateLivemode(ctx context.Context, client *client.API, id string) (bool, error) {
+	params := &stripe.ShippingRateParams{}
+	params.Context = ctx
+
+	shippingRate, err := client.ShippingRates.Get(id, params)
+	if err != nil {
+		return false, err
+	}
+
+	return shippingRate.Livemode, nil
+}
+
+func resourceStripeShippingRateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.ShippingRateParams{}
+	params.Context = ctx
+
+	shippingRate, err := client.ShippingRates.Get(d.Id(), params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("display_name", shippingRate.DisplayName)
+	d.Set("type", shippingRate.Type)
+	if shippingRate.FixedAmount != nil {
+		d.Set("fixed_amount", []map[string]interface{}{
+			{
+				"amount":   shippingRate.FixedAmount.Amount,
+				"currency": string(shippingRate.FixedAmount.Currency),
+			},
+		})
+	}
+	d.Set("tax_behavior", shippingRate.TaxBehavior)
+	if shippingRate.TaxCode != nil {
+		d.Set("tax_code", shippingRate.TaxCode.ID)
+	} else {
+		d.Set("tax_code", "")
+	}
+	d.Set("delivery_estimate", flattenShippingRateDeliveryEstimate(shippingRate.DeliveryEstimate))
+	d.Set("active", shippingRate.Active)
+	d.Set("metadata", shippingRate.Metadata)
+	d.Set("created", shippingRate.Created)
+	d.Set("livemode", shippingRate.Livemode)
+
+	return nil
+}
+
+func resourceStripeShippingRateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.ShippingRateParams{}
+	params.Context = ctx
+
+	if d.HasChange("active") {
+		params.Active = stripe.Bool(d.Get("active").(bool))
+	}
+
+	if d.HasChange("tax_behavior") {
+		params.TaxBehavior = stripe.String(d.Get("tax_behavior").(string))
+	}
+
+	if d.HasChange("metadata") {
+		metadata, diags := expandMetadata(d)
+		if diags.HasError() {
+			return diags
+		}
+		params.Metadata = metadata
+	}
+
+	if _, err := client.ShippingRates.Update(d.Id(), params); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStripeShippingRateRead(ctx, d, m)
+}
+
+func resourceStripeShippingRateDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	// Stripe doesn't allow deleting shipping rates, archive it instead.
+	params := &stripe.ShippingRateParams{
+		Active: stripe.Bool(false),
+	}
+	params.Context = ctx
+
+	if _, err := client.ShippingRates.Update(d.Id(), params); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
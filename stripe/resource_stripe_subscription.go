@@ -0,0 +1,631 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	stripe "github.com/stripe/stripe-go/v72"
+	"github.com/stripe/stripe-go/v72/client"
+)
+
+func resourceStripeSubscription() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceStripeSubscriptionCreate,
+		ReadContext:   resourceStripeSubscriptionRead,
+		UpdateContext: resourceStripeSubscriptionUpdate,
+		DeleteContext: resourceStripeSubscriptionDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateCheckingLivemode(resourceStripeSubscriptionLivemode),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"customer": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"items": {
+				Type: schema.TypeList,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"price": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"quantity": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Computed: true,
+						},
+						"metadata": {
+							Type: schema.TypeMap,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Optional: true,
+						},
+						// Only read when expand_item_prices is set.
+						"price_details": {
+							Type: schema.TypeList,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"currency": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"unit_amount": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+									"nickname": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"lookup_key": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"product": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"product_name": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"product_description": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+							Computed: true,
+						},
+					},
+				},
+				MinItems: 1,
+				Required: true,
+			},
+			// Expanding the prices and products of the items makes reads
+			// heavier, so it's opt-in.
+			"expand_item_prices": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"default_payment_method": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true, // inherited from the customer when omitted
+			},
+			"trial_end": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"cancel_at_period_end": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"proration_behavior": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateEnum("create_prorations", "none", "always_invoice"),
+			},
+			"collection_method": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateEnum("charge_automatically", "send_invoice"),
+			},
+			"days_until_due": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"coupon": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			// Connect: the platform's cut of each invoice, in percent.
+			"application_fee_percent": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				ValidateFunc: validation.FloatBetween(0, 100),
+			},
+			"transfer_data": {
+				Type: schema.TypeList,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"destination": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateAccountID,
+						},
+						// Share of each invoice transferred, all of it when omitted.
+						"amount_percent": {
+							Type:         schema.TypeFloat,
+							Optional:     true,
+							ValidateFunc: validation.FloatBetween(0, 100),
+						},
+					},
+				},
+				MaxItems: 1,
+				Optional: true,
+			},
+			"metadata": {
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"current_period_end": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"latest_invoice": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"livemode": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func expandSubscriptionItems(d *schema.ResourceData) ([]*stripe.SubscriptionItemsParams, diag.Diagnostics) {
+	old, new := d.GetChange("items")
+	oldItems := old.([]interface{})
+	newItems := new.([]interface{})
+
+	// The computed ID of an item is taken from the state by position, so it
+	// is only kept when the item at that position still has the same price.
+	// Other items are matched to a previous item with the same price, if any.
+	previous := make([]map[string]interface{}, len(newItems))
+	claimed := make(map[int]bool)
+	for i, v := range newItems {
+		item := v.(map[string]interface{})
+		if i < len(oldItems) {
+			if o := oldItems[i].(map[string]interface{}); o["id"].(string) != "" && o["price"] == item["price"] {
+				previous[i] = o
+				claimed[i] = true
+			}
+		}
+	}
+	for i, v := range newItems {
+		if previous[i] != nil {
+			continue
+		}
+		item := v.(map[string]interface{})
+		for j, w := range oldItems {
+			if o := w.(map[string]interface{}); !claimed[j] && o["id"].(string) != "" && o["price"] == item["price"] {
+				previous[i] = o
+				claimed[j] = true
+				break
+			}
+		}
+	}
+
+	out := make([]*stripe.SubscriptionItemsParams, 0)
+	for i, v := range newItems {
+		item := v.(map[string]interface{})
+		params := &stripe.SubscriptionItemsParams{
+			Price: stripe.String(item["price"].(string)),
+		}
+
+		// Metered prices don't accept any quantity.
+		if quantity := item["quantity"].(int); quantity > 0 {
+			params.Quantity = stripe.Int64(int64(quantity))
+		}
+
+		metadata := make(map[string]string)
+		if previous[i] != nil {
+			params.ID = stripe.String(previous[i]["id"].(string))
+
+			// Clear the keys that were removed from the item.
+			oldMetadata, _ := previous[i]["metadata"].(map[string]interface{})
+			for key := range oldMetadata {
+				metadata[key] = ""
+			}
+		}
+		configured, err := expandStringMap(item["metadata"].(map[string]interface{}))
+		if err != nil {
+			return nil, diag.Errorf("items.%d.metadata: %s", i, err)
+		}
+		for key, value := range configured {
+			metadata[key] = value
+		}
+		if len(metadata) > 0 {
+			params.Metadata = metadata
+		}
+
+		out = append(out, params)
+	}
+
+	for j, v := range oldItems {
+		id := v.(map[string]interface{})["id"].(string)
+		if id != "" && !claimed[j] {
+			out = append(out, &stripe.SubscriptionItemsParams{
+				ID:      stripe.String(id),
+				Deleted: stripe.Bool(true),
+			})
+		}
+	}
+
+	return out, nil
+}
+
+// flattenSubscriptionItems keeps items in the order they have in the state,
+// since Stripe doesn't guarantee any and several items may share a price.
+// Items are matched by ID, then those without one yet by price and metadata,
+// then by price alone.
+func flattenSubscriptionItems(in []*stripe.SubscriptionItem, current []interface{}, expanded bool) []map[string]interface{} {
+	known := make([]*stripe.SubscriptionItem, len(current))
+	matched := make(map[string]bool)
+
+	match := func(same func(item *stripe.SubscriptionItem, state map[string]interface{}) bool) {
+		for i, v := range current {
+			state, ok := v.(map[string]interface{})
+			if !ok || known[i] != nil {
+				continue
+			}
+			for _, item := range in {
+				if !matched[item.ID] && same(item, state) {
+					known[i] = item
+					matched[item.ID] = true
+					break
+				}
+			}
+		}
+	}
+	match(func(item *stripe.SubscriptionItem, state map[string]interface{}) bool {
+		return item.ID == state["id"]
+	})
+	match(func(item *stripe.SubscriptionItem, state map[string]interface{}) bool {
+		return state["id"] == "" && subscriptionItemPrice(item) == state["price"] && subscriptionItemMetadataEqual(item, state)
+	})
+	match(func(item *stripe.SubscriptionItem, state map[string]interface{}) bool {
+		return state["id"] == "" && subscriptionItemPrice(item) == state["price"]
+	})
+
+	for _, item := range in {
+		if !matched[item.ID] {
+			known = append(known, item)
+		}
+	}
+
+	out := make([]map[string]interface{}, 0, len(in))
+	for _, item := range known {
+		if item == nil {
+			continue
+		}
+
+		priceDetails := []map[string]interface{}{}
+		if expanded && item.Price != nil {
+			priceDetails = append(priceDetails, flattenSubscriptionItemPrice(item.Price))
+		}
+
+		out = append(out, map[string]interface{}{
+			"id":            item.ID,
+			"price":         subscriptionItemPrice(item),
+			"quantity":      item.Quantity,
+			"metadata":      item.Metadata,
+			"price_details": priceDetails,
+		})
+	}
+	return out
+}
+
+func subscriptionItemPrice(item *stripe.SubscriptionItem) string {
+	if item.Price == nil {
+		return ""
+	}
+	return item.Price.ID
+}
+
+func subscriptionItemMetadataEqual(item *stripe.SubscriptionItem, state map[string]interface{}) bool {
+	metadata, _ := state["metadata"].(map[string]interface{})
+	if len(metadata) != len(item.Metadata) {
+		return false
+	}
+	for key, value := range item.Metadata {
+		if metadata[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+func flattenSubscriptionItemPrice(in *stripe.Price) map[string]interface{} {
+	out := map[string]interface{}{
+		"currency":    in.Currency,
+		"unit_amount": in.UnitAmount,
+		"nickname":    in.Nickname,
+		"lookup_key":  in.LookupKey,
+	}
+
+	if in.Product != nil {
+		out["product"] = in.Product.ID
+		out["product_name"] = in.Product.Name
+		out["product_description"] = in.Product.Description
+	}
+
+	return out
+}
+
+func expandSubscriptionTransferData(d *schema.ResourceData) *stripe.SubscriptionTransferDataParams {
+	params := &stripe.SubscriptionTransferDataParams{
+		Destination: stripe.String(d.Get("transfer_data.0.destination").(string)),
+	}
+
+	if amountPercent, ok := d.GetOk("transfer_data.0.amount_percent"); ok {
+		params.AmountPercent = stripe.Float64(amountPercent.(float64))
+	}
+
+	return params
+}
+
+func resourceStripeSubscriptionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+	customer := d.Get("customer").(string)
+
+	items, diags := expandSubscriptionItems(d)
+	if diags.HasError() {
+		return diags
+	}
+
+	params := &stripe.SubscriptionParams{
+		Customer: stripe.String(customer),
+		Items:    items,
+	}
+	params.Context = ctx
+
+	if defaultPaymentMethod, ok := d.GetOk("default_payment_method"); ok {
+		params.DefaultPaymentMethod = stripe.String(defaultPaymentMethod.(string))
+	}
+
+	if trialEnd, ok := d.GetOk("trial_end"); ok {
+		params.TrialEnd = stripe.Int64(int64(trialEnd.(int)))
+	}
+
+	if cancelAtPeriodEnd, ok := d.GetOk("cancel_at_period_end"); ok {
+		params.CancelAtPeriodEnd = stripe.Bool(cancelAtPeriodEnd.(bool))
+	}
+
+	if prorationBehavior, ok := d.GetOk("proration_behavior"); ok {
+		params.ProrationBehavior = stripe.String(prorationBehavior.(string))
+	}
+
+	if collectionMethod, ok := d.GetOk("collection_method"); ok {
+		params.CollectionMethod = stripe.String(collectionMethod.(string))
+	}
+
+	if daysUntilDue, ok := d.GetOk("days_until_due"); ok {
+		params.DaysUntilDue = stripe.Int64(int64(daysUntilDue.(int)))
+	}
+
+	if coupon, ok := d.GetOk("coupon"); ok {
+		params.Coupon = stripe.String(coupon.(string))
+	}
+
+	if applicationFeePercent, ok := d.GetOk("application_fee_percent"); ok {
+		params.ApplicationFeePercent = stripe.Float64(applicationFeePercent.(float64))
+	}
+
+	if _, ok := d.GetOk("transfer_data"); ok {
+		params.TransferData = expandSubscriptionTransferData(d)
+	}
+
+	metadata, diags := expandMetadata(d)
+	if diags.HasError() {
+		return diags
+	}
+	params.Metadata = metadata
+
+	setIdempotencyKey(params, d)
+	subscription, err := client.Subscriptions.New(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Create subscription: %s (%s)", subscription.ID, customer)
+	d.SetId(subscription.ID)
+
+	return resourceStripeSubscriptionRead(ctx, d, m)
+}
+
+func resourceStripeSubscriptionLivemode(ctx context.Context, client *client.API, id string) (bool, error) {
+	params := &stripe.SubscriptionParams{}
+	params.Context = ctx
+
+	subscription, err := client.Subscriptions.Get(id, params)
+	if err != nil {
+		return false, err
+	}
+
+	return subscription.Livemode, nil
+}
+
+func resourceStripeSubscriptionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.SubscriptionParams{}
+	params.Context = ctx
+
+	expandItemPrices := d.Get("expand_item_prices").(bool)
+	if expandItemPrices {
+		params.AddExpand("items.data.price.product")
+	}
+
+	subscription, err := client.Subscriptions.Get(d.Id(), params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	items := subscription.Items.Data
+	if subscription.Items.HasMore {
+		items, err = listSubscriptionItems(ctx, client, subscription.ID, expandItemPrices)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if subscription.Customer != nil {
+		d.Set("customer", subscription.Customer.ID)
+	}
+	d.Set("items", flattenSubscriptionItems(items, d.Get("items").([]interface{}), expandItemPrices))
+	if subscription.DefaultPaymentMethod != nil {
+		d.Set("default_payment_method", subscription.DefaultPaymentMethod.ID)
+	} else {
+		d.Set("default_payment_method", "")
+	}
+	d.Set("trial_end", subscription.TrialEnd)
+	d.Set("cancel_at_period_end", subscription.CancelAtPeriodEnd)
+	d.Set("collection_method", subscription.CollectionMethod)
+	d.Set("days_until_due", subscription.DaysUntilDue)
+	if subscription.Discount != nil && subscription.Discount.Coupon != nil {
+		d.Set("coupon", subscription.Discount.Coupon.ID)
+	} else {
+		d.Set("coupon", "")
+	}
+	d.Set("application_fee_percent", subscription.ApplicationFeePercent)
+	transferData := []map[string]interface{}{}
+	if subscription.TransferData != nil && subscription.TransferData.Destination != nil {
+		transferData = append(transferData, map[string]interface{}{
+			"destination":    subscription.TransferData.Destination.ID,
+			"amount_percent": subscription.TransferData.AmountPercent,
+		})
+	}
+	d.Set("transfer_data", transferData)
+	d.Set("metadata", subscription.Metadata)
+	d.Set("status", subscription.Status)
+	d.Set("current_period_end", subscription.CurrentPeriodEnd)
+	if subscription.LatestInvoice != nil {
+		d.Set("latest_invoice", subscription.LatestInvoice.ID)
+	}
+	d.Set("livemode", subscription.Livemode)
+
+	return nil
+}
+
+func listSubscriptionItems(ctx context.Context, client *client.API, subscription string, expandPrices bool) ([]*stripe.SubscriptionItem, error) {
+	params := &stripe.SubscriptionItemListParams{
+		Subscription: stripe.String(subscription),
+	}
+	params.Context = ctx
+
+	if expandPrices {
+		params.AddExpand("data.price.product")
+	}
+
+	items, err := listAll[*stripe.SubscriptionItem](ctx, client.SubscriptionItems.List(params), 0, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list the items of subscription %s: %s", subscription, err)
+	}
+
+	return items, nil
+}
+
+func resourceStripeSubscriptionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.SubscriptionParams{}
+	params.Context = ctx
+
+	if d.HasChange("items") {
+		items, diags := expandSubscriptionItems(d)
+		if diags.HasError() {
+			return diags
+		}
+		params.Items = items
+	}
+
+	if d.HasChange("default_payment_method") {
+		params.DefaultPaymentMethod = stripe.String(d.Get("default_payment_method").(string))
+	}
+
+	if d.HasChange("trial_end") {
+		params.TrialEnd = stripe.Int64(int64(d.Get("trial_end").(int)))
+	}
+
+	if d.HasChange("cancel_at_period_end") {
+		params.CancelAtPeriodEnd = stripe.Bool(d.Get("cancel_at_period_end").(bool))
+	}
+
+	if prorationBehavior, ok := d.GetOk("proration_behavior"); ok {
+		params.ProrationBehavior = stripe.String(prorationBehavior.(string))
+	}
+
+	if d.HasChange("collection_method") {
+		params.CollectionMethod = stripe.String(d.Get("collection_method").(string))
+	}
+
+	if d.HasChange("days_until_due") {
+		params.DaysUntilDue = stripe.Int64(int64(d.Get("days_until_due").(int)))
+	}
+
+	if d.HasChange("coupon") {
+		params.Coupon = stripe.String(d.Get("coupon").(string))
+	}
+
+	if d.HasChange("application_fee_percent") {
+		if applicationFeePercent, ok := d.GetOk("application_fee_percent"); ok {
+			params.ApplicationFeePercent = stripe.Float64(applicationFeePercent.(float64))
+		} else {
+			params.AddExtra("application_fee_percent", "")
+		}
+	}
+
+	if d.HasChange("transfer_data") {
+		if _, ok := d.GetOk("transfer_data"); ok {
+			params.TransferData = expandSubscriptionTransferData(d)
+		} else {
+			params.AddExtra("transfer_data", "")
+		}
+	}
+
+	if d.HasChange("metadata") {
+		metadata, diags := expandMetadata(d)
+		if diags.HasError() {
+			return diags
+		}
+		params.Metadata = metadata
+	}
+
+	if _, err := client.Subscriptions.Update(d.Id(), params); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStripeSubscriptionRead(ctx, d, m)
+}
+
+func resourceStripeSubscriptionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.SubscriptionCancelParams{}
+	params.Context = ctx
+
+	if _, err := client.Subscriptions.Cancel(d.Id(), params); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
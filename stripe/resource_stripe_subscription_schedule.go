@@ -0,0 +1,439 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	stripe "github.com/stripe/stripe-go/v72"
+	"github.com/stripe/stripe-go/v72/client"
+)
+
+func resourceStripeSubscriptionSchedule() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceStripeSubscriptionScheduleCreate,
+		ReadContext:   resourceStripeSubscriptionScheduleRead,
+		UpdateContext: resourceStripeSubscriptionScheduleUpdate,
+		DeleteContext: resourceStripeSubscriptionScheduleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateCheckingLivemode(resourceStripeSubscriptionScheduleLivemode),
+		},
+		CustomizeDiff: resourceStripeSubscriptionScheduleCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"customer": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			// Unix timestamp, the schedule starts right away when omitted.
+			"start_date": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"end_behavior": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "release",
+				ValidateFunc: validateEnum("release", "cancel"),
+			},
+			"phases": {
+				Type: schema.TypeList,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"items": {
+							Type: schema.TypeList,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"price": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"quantity": {
+										Type:     schema.TypeInt,
+										Optional: true,
+										Computed: true,
+									},
+								},
+							},
+							MinItems: 1,
+							Required: true,
+						},
+						// Stripe turns iterations into an end date, so only
+						// the configured value is kept.
+						"iterations": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"end_date": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Computed: true,
+						},
+						"coupon": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"proration_behavior": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validateEnum("create_prorations", "none", "always_invoice"),
+						},
+					},
+				},
+				MinItems: 1,
+				Required: true,
+			},
+			"metadata": {
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional: true,
+			},
+			// Computed
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"subscription": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"current_phase": {
+				Type: schema.TypeList,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"start_date": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"end_date": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+				Computed: true,
+			},
+			// -1 when the schedule hasn't started or has ended.
+			"current_phase_index": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"livemode": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// resourceStripeSubscriptionScheduleCustomizeDiff rejects changes to the
+// phases that ended before the current one started, Stripe keeps them as they
+// were.
+func resourceStripeSubscriptionScheduleCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !d.HasChange("phases") {
+		return nil
+	}
+
+	o, n := d.GetChange("phases")
+	old, new := o.([]interface{}), n.([]interface{})
+
+	completed := completedSubscriptionSchedulePhases(old, currentSubscriptionSchedulePhaseStart(d.Get("current_phase").([]interface{})))
+	if len(new) <= completed {
+		return fmt.Errorf("phases: the %d completed phase(s) must be followed by the current and upcoming ones", completed)
+	}
+	for i := 0; i < completed; i++ {
+		if !reflect.DeepEqual(old[i], new[i]) {
+			return fmt.Errorf("phases.%d: can't be changed, the phase is completed", i)
+		}
+	}
+
+	return nil
+}
+
+func currentSubscriptionSchedulePhaseStart(in []interface{}) int {
+	if len(in) == 0 || in[0] == nil {
+		return 0
+	}
+
+	return in[0].(map[string]interface{})["start_date"].(int)
+}
+
+// completedSubscriptionSchedulePhases counts the leading phases that ended by
+// the start of the current one.
+func completedSubscriptionSchedulePhases(in []interface{}, currentStart int) int {
+	if currentStart == 0 {
+		return 0
+	}
+
+	completed := 0
+	for _, v := range in {
+		endDate := v.(map[string]interface{})["end_date"].(int)
+		if endDate == 0 || endDate > currentStart {
+			break
+		}
+		completed++
+	}
+
+	return completed
+}
+
+// expandSubscriptionSchedulePhases sends every phase, as Stripe replaces the
+// whole list on update. A phase lasts for its iterations when set, until its
+// end date otherwise.
+func expandSubscriptionSchedulePhases(in []interface{}) []*stripe.SubscriptionSchedulePhaseParams {
+	out := make([]*stripe.SubscriptionSchedulePhaseParams, 0, len(in))
+	for _, v := range in {
+		phase := v.(map[string]interface{})
+		params := &stripe.SubscriptionSchedulePhaseParams{}
+
+		for _, i := range phase["items"].([]interface{}) {
+			item := i.(map[string]interface{})
+			itemParams := &stripe.SubscriptionSchedulePhaseItemParams{
+				Price: stripe.String(item["price"].(string)),
+			}
+
+			// Metered prices don't accept any quantity.
+			if quantity := item["quantity"].(int); quantity > 0 {
+				itemParams.Quantity = stripe.Int64(int64(quantity))
+			}
+
+			params.Items = append(params.Items, itemParams)
+		}
+
+		if iterations := phase["iterations"].(int); iterations > 0 {
+			params.Iterations = stripe.Int64(int64(iterations))
+		} else if endDate := phase["end_date"].(int); endDate > 0 {
+			params.EndDate = stripe.Int64(int64(endDate))
+		}
+
+		if coupon := phase["coupon"].(string); coupon != "" {
+			params.Coupon = stripe.String(coupon)
+		}
+
+		if prorationBehavior := phase["proration_behavior"].(string); prorationBehavior != "" {
+			params.ProrationBehavior = stripe.String(prorationBehavior)
+		}
+
+		out = append(out, params)
+	}
+
+	return out
+}
+
+func flattenSubscriptionSchedulePhases(in []*stripe.SubscriptionSchedulePhase, current []interface{}) []map[string]interface{} {
+	out := make([]map[string]interface{}, 0, len(in))
+	for i, phase := range in {
+		items := make([]map[string]interface{}, 0, len(phase.Items))
+		for _, item := range phase.Items {
+			price := ""
+			if item.Price != nil {
+				price = item.Price.ID
+			}
+
+			items = append(items, map[string]interface{}{
+				"price":    price,
+				"quantity": item.Quantity,
+			})
+		}
+
+		iterations := 0
+		if i < len(current) {
+			if c, ok := current[i].(map[string]interface{}); ok {
+				iterations = c["iterations"].(int)
+			}
+		}
+
+		coupon := ""
+		if phase.Coupon != nil {
+			coupon = phase.Coupon.ID
+		}
+
+		out = append(out, map[string]interface{}{
+			"items":              items,
+			"iterations":         iterations,
+			"end_date":           phase.EndDate,
+			"coupon":             coupon,
+			"proration_behavior": phase.ProrationBehavior,
+		})
+	}
+
+	return out
+}
+
+func resourceStripeSubscriptionScheduleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+	customer := d.Get("customer").(string)
+
+	params := &stripe.SubscriptionScheduleParams{
+		Customer:    stripe.String(customer),
+		EndBehavior: stripe.String(d.Get("end_behavior").(string)),
+		Phases:      expandSubscriptionSchedulePhases(d.Get("phases").([]interface{})),
+	}
+	params.Context = ctx
+
+	if startDate, ok := d.GetOk("start_date"); ok {
+		params.StartDate = stripe.Int64(int64(startDate.(int)))
+	} else {
+		params.StartDateNow = stripe.Bool(true)
+	}
+
+	metadata, diags := expandMetadata(d)
+	if diags.HasError() {
+		return diags
+	}
+	params.Metadata = metadata
+
+	setIdempotencyKey(params, d)
+	schedule, err := client.SubscriptionSchedules.New(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Create subscription schedule: %s (%s)", schedule.ID, customer)
+	d.SetId(schedule.ID)
+
+	return resourceStripeSubscriptionScheduleRead(ctx, d, m)
+}
+
+func resourceStripeSubscriptionScheduleLivemode(ctx context.Context, client *client.API, id string) (bool, error) {
+	params := &stripe.SubscriptionScheduleParams{}
+	params.Context = ctx
+
+	schedule, err := client.SubscriptionSchedules.Get(id, params)
+	if err != nil {
+		return false, err
+	}
+
+	return schedule.Livemode, nil
+}
+
+func resourceStripeSubscriptionScheduleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.SubscriptionScheduleParams{}
+	params.Context = ctx
+
+	schedule, err := client.SubscriptionSchedules.Get(d.Id(), params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if schedule.Customer != nil {
+		d.Set("customer", schedule.Customer.ID)
+	}
+	if len(schedule.Phases) > 0 {
+		d.Set("start_date", schedule.Phases[0].StartDate)
+	}
+	d.Set("end_behavior", schedule.EndBehavior)
+	d.Set("phases", flattenSubscriptionSchedulePhases(schedule.Phases, d.Get("phases").([]interface{})))
+	d.Set("metadata", schedule.Metadata)
+	d.Set("status", schedule.Status)
+	if schedule.Subscription != nil {
+		d.Set("subscription", schedule.Subscription.ID)
+	} else {
+		d.Set("subscription", "")
+	}
+	currentPhase := []map[string]interface{}{}
+	currentPhaseIndex := -1
+	if schedule.CurrentPhase != nil {
+		currentPhase = append(currentPhase, map[string]interface{}{
+			"start_date": schedule.CurrentPhase.StartDate,
+			"end_date":   schedule.CurrentPhase.EndDate,
+		})
+		for i, phase := range schedule.Phases {
+			if phase.StartDate == schedule.CurrentPhase.StartDate {
+				currentPhaseIndex = i
+				break
+			}
+		}
+	}
+	d.Set("current_phase", currentPhase)
+	d.Set("current_phase_index", currentPhaseIndex)
+	d.Set("livemode", schedule.Livemode)
+
+	return nil
+}
+
+func resourceStripeSubscriptionScheduleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.SubscriptionScheduleParams{}
+	params.Context = ctx
+
+	if d.HasChange("end_behavior") {
+		params.EndBehavior = stripe.String(d.Get("end_behavior").(string))
+	}
+
+	if d.HasChange("phases") {
+		phases := expandSubscriptionSchedulePhases(d.Get("phases").([]interface{}))
+		startDate := d.Get("start_date").(int)
+
+		// Completed phases are left out, the update starts with the current
+		// one, which keeps its start date.
+		if currentStart := currentSubscriptionSchedulePhaseStart(d.Get("current_phase").([]interface{})); currentStart > 0 {
+			o, _ := d.GetChange("phases")
+			phases = phases[completedSubscriptionSchedulePhases(o.([]interface{}), currentStart):]
+			startDate = currentStart
+		}
+
+		// Stripe needs to know where the first phase starts when they are
+		// updated, which can't change anymore.
+		phases[0].StartDate = stripe.Int64(int64(startDate))
+		params.Phases = phases
+	}
+
+	if d.HasChange("metadata") {
+		metadata, diags := expandMetadata(d)
+		if diags.HasError() {
+			return diags
+		}
+		params.Metadata = metadata
+	}
+
+	if _, err := client.SubscriptionSchedules.Update(d.Id(), params); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStripeSubscriptionScheduleRead(ctx, d, m)
+}
+
+// resourceStripeSubscriptionScheduleDelete follows end_behavior: the
+// subscription keeps running once a schedule is released, and is canceled
+// along with it otherwise. Schedules that already ended are left as is.
+func resourceStripeSubscriptionScheduleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	switch stripe.SubscriptionScheduleStatus(d.Get("status").(string)) {
+	case stripe.SubscriptionScheduleStatusActive, stripe.SubscriptionScheduleStatusNotStarted:
+		if d.Get("end_behavior").(string) == "cancel" {
+			params := &stripe.SubscriptionScheduleCancelParams{}
+			params.Context = ctx
+
+			if _, err := client.SubscriptionSchedules.Cancel(d.Id(), params); err != nil {
+				return diag.FromErr(err)
+			}
+		} else {
+			params := &stripe.SubscriptionScheduleReleaseParams{}
+			params.Context = ctx
+
+			if _, err := client.SubscriptionSchedules.Release(d.Id(), params); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	default:
+		log.Printf("[WARN] Subscription schedule %s is %s, removing it from the state only", d.Id(), d.Get("status").(string))
+	}
+
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,147 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceStripeSubscriptionScheduleCustomizeDiff(t *testing.T) {
+	phase := func(price string, endDate int) map[string]interface{} {
+		return map[string]interface{}{
+			"items":    []interface{}{map[string]interface{}{"price": price, "quantity": 1}},
+			"end_date": endDate,
+		}
+	}
+
+	cases := []struct {
+		name   string
+		phases []interface{}
+		err    string
+	}{
+		{
+			name:   "unchanged",
+			phases: []interface{}{phase("price_a", 100), phase("price_b", 200), phase("price_c", 300)},
+		},
+		{
+			name:   "current phase changed",
+			phases: []interface{}{phase("price_a", 100), phase("price_x", 200), phase("price_c", 300)},
+		},
+		{
+			name:   "upcoming phase added",
+			phases: []interface{}{phase("price_a", 100), phase("price_b", 200), phase("price_c", 300), phase("price_d", 400)},
+		},
+		{
+			name:   "completed phase changed",
+			phases: []interface{}{phase("price_x", 100), phase("price_b", 200), phase("price_c", 300)},
+			err:    "phases.0: can't be changed",
+		},
+		{
+			name:   "current phase removed",
+			phases: []interface{}{phase("price_a", 100)},
+			err:    "must be followed by the current and upcoming ones",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := resourceStripeSubscriptionSchedule()
+			d := r.TestResourceData()
+			d.SetId("sub_sched_123")
+			d.Set("customer", "cus_123")
+			d.Set("start_date", 50)
+			d.Set("end_behavior", "release")
+			d.Set("phases", []interface{}{phase("price_a", 100), phase("price_b", 200), phase("price_c", 300)})
+			d.Set("current_phase", []interface{}{map[string]interface{}{"start_date": 100, "end_date": 200}})
+
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"customer":     "cus_123",
+				"end_behavior": "release",
+				"phases":       tc.phases,
+			})
+
+			_, err := r.Diff(context.Background(), d.State(), config, nil)
+			if tc.err == "" && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if tc.err != "" && (err == nil || !strings.Contains(err.Error(), tc.err)) {
+				t.Fatalf("expected error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestCompletedSubscriptionSchedulePhases(t *testing.T) {
+	phases := []interface{}{
+		map[string]interface{}{"end_date": 100},
+		map[string]interface{}{"end_date": 200},
+		map[string]interface{}{"end_date": 0},
+	}
+
+	for currentStart, want := range map[int]int{0: 0, 50: 0, 100: 1, 200: 2, 500: 2} {
+		if got := completedSubscriptionSchedulePhases(phases, currentStart); got != want {
+			t.Errorf("completed phases with the current one starting at %d: expected %d, got %d", currentStart, want, got)
+		}
+	}
+}
+
+func TestResourceStripeSubscriptionScheduleReadCurrentPhaseIndex(t *testing.T) {
+	phase := func(start, end int) string {
+		return fmt.Sprintf(`{"start_date": %d, "end_date": %d, "items": [{"price": {"id": "price_%d", "object": "price"}, "quantity": 1}]}`, start, end, start)
+	}
+	phases := phase(100, 200) + "," + phase(200, 300) + "," + phase(300, 400)
+
+	cases := []struct {
+		name         string
+		currentPhase string
+		want         int
+	}{
+		{
+			name:         "first phase",
+			currentPhase: `{"start_date": 100, "end_date": 200}`,
+			want:         0,
+		},
+		{
+			name:         "middle phase",
+			currentPhase: `{"start_date": 200, "end_date": 300}`,
+			want:         1,
+		},
+		{
+			name:         "last phase",
+			currentPhase: `{"start_date": 300, "end_date": 400}`,
+			want:         2,
+		},
+		{
+			name:         "not started",
+			currentPhase: `null`,
+			want:         -1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := testProviderMeta(t, func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprintf(w, `{"id": "sub_sched_123", "object": "subscription_schedule", "status": "active", "phases": [%s], "current_phase": %s}`, phases, tc.currentPhase)
+			})
+
+			r := resourceStripeSubscriptionSchedule()
+			d := r.TestResourceData()
+			d.SetId("sub_sched_123")
+
+			if diags := r.ReadContext(context.Background(), d, m); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			if got := d.Get("phases.#").(int); got != 3 {
+				t.Fatalf("expected 3 phases, got %d", got)
+			}
+			if got := d.Get("current_phase_index").(int); got != tc.want {
+				t.Errorf("expected current phase index %d, got %d", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const testSubscriptionItemJSON = `{
+	"id": "si_%d",
+	"object": "subscription_item",
+	"quantity": 1,
+	"price": {
+		"id": "price_%d",
+		"object": "price",
+		"currency": "usd",
+		"unit_amount": 1500,
+		"nickname": "Monthly",
+		"lookup_key": "monthly",
+		"product": {
+			"id": "prod_%d",
+			"object": "product",
+			"name": "Seat",
+			"description": "One seat"
+		}
+	}
+}`
+
+func TestResourceStripeSubscriptionReadExpandItemPrices(t *testing.T) {
+	m := testProviderMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/subscriptions/sub_123":
+			if got := r.URL.Query().Get("expand[0]"); got != "items.data.price.product" {
+				t.Errorf("unexpected subscription expansion %q", got)
+			}
+			fmt.Fprintf(w, `{"id": "sub_123", "object": "subscription", "items": {"object": "list", "has_more": true, "data": [`+testSubscriptionItemJSON+`]}}`, 1, 1, 1)
+		case "/v1/subscription_items":
+			if got := r.URL.Query().Get("expand[0]"); got != "data.price.product" {
+				t.Errorf("unexpected subscription items expansion %q", got)
+			}
+			fmt.Fprintf(w, `{"object": "list", "has_more": false, "data": [`+testSubscriptionItemJSON+`,`+testSubscriptionItemJSON+`]}`, 1, 1, 1, 2, 2, 2)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	r := resourceStripeSubscription()
+	d := r.TestResourceData()
+	d.SetId("sub_123")
+	d.Set("expand_item_prices", true)
+
+	if diags := r.ReadContext(context.Background(), d, m); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if got := d.Get("items.#").(int); got != 2 {
+		t.Fatalf("expected 2 items, got %d", got)
+	}
+
+	for key, want := range map[string]interface{}{
+		"items.1.id":                                  "si_2",
+		"items.1.price":                               "price_2",
+		"items.1.price_details.0.currency":            "usd",
+		"items.1.price_details.0.unit_amount":         1500,
+		"items.1.price_details.0.lookup_key":          "monthly",
+		"items.1.price_details.0.product":             "prod_2",
+		"items.1.price_details.0.product_name":        "Seat",
+		"items.1.price_details.0.product_description": "One seat",
+	} {
+		if got := d.Get(key); got != want {
+			t.Errorf("%s: expected %v, got %v", key, want, got)
+		}
+	}
+}
+
+func TestResourceStripeSubscriptionReadWithoutExpansion(t *testing.T) {
+	m := testProviderMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("expand[0]"); got != "" {
+			t.Errorf("unexpected expansion %q", got)
+		}
+		fmt.Fprintf(w, `{"id": "sub_123", "object": "subscription", "items": {"object": "list", "data": [`+testSubscriptionItemJSON+`]}}`, 1, 1, 1)
+	})
+
+	r := resourceStripeSubscription()
+	d := r.TestResourceData()
+	d.SetId("sub_123")
+
+	if diags := r.ReadContext(context.Background(), d, m); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if got := d.Get("items.0.price_details.#").(int); got != 0 {
+		t.Errorf("expected no price details, got %d", got)
+	}
+}
+
+func testSubscriptionItemsConfig(items ...map[string]interface{}) map[string]interface{} {
+	config := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		config = append(config, item)
+	}
+	return map[string]interface{}{
+		"customer": "cus_123",
+		"items":    config,
+	}
+}
+
+func TestResourceStripeSubscriptionItemsSharingAPrice(t *testing.T) {
+	m := testProviderMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		// Stripe doesn't keep the order the items were created in.
+		fmt.Fprint(w, `{"id": "sub_123", "object": "subscription", "items": {"object": "list", "data": [
+			{"id": "si_2", "object": "subscription_item", "quantity": 1, "metadata": {"seat": "b"}, "price": {"id": "price_1", "object": "price"}},
+			{"id": "si_1", "object": "subscription_item", "quantity": 1, "metadata": {"seat": "a"}, "price": {"id": "price_1", "object": "price"}}
+		]}}`)
+	})
+
+	config := testSubscriptionItemsConfig(
+		map[string]interface{}{"price": "price_1", "quantity": 1, "metadata": map[string]interface{}{"seat": "a"}},
+		map[string]interface{}{"price": "price_1", "quantity": 1, "metadata": map[string]interface{}{"seat": "b"}},
+	)
+
+	r := resourceStripeSubscription()
+	d := r.TestResourceData()
+	d.SetId("sub_123")
+	d.Set("customer", "cus_123")
+	d.Set("items", config["items"])
+	d.Set("expand_item_prices", false)
+
+	// The first read after the create matches the items by price and metadata.
+	if diags := r.ReadContext(context.Background(), d, m); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	for key, want := range map[string]interface{}{
+		"items.0.id":            "si_1",
+		"items.0.metadata.seat": "a",
+		"items.1.id":            "si_2",
+		"items.1.metadata.seat": "b",
+	} {
+		if got := d.Get(key); got != want {
+			t.Errorf("%s: expected %v, got %v", key, want, got)
+		}
+	}
+
+	// Later reads match them by ID and keep them stable.
+	if diags := r.ReadContext(context.Background(), d, m); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !diff.Empty() {
+		t.Errorf("expected no diff, got %v", diff)
+	}
+}
+
+func TestExpandSubscriptionItemsRemovingFirstItem(t *testing.T) {
+	r := resourceStripeSubscription()
+	state := r.TestResourceData()
+	state.SetId("sub_123")
+	state.Set("customer", "cus_123")
+	state.Set("items", []interface{}{
+		map[string]interface{}{"id": "si_1", "price": "price_1", "quantity": 1},
+		map[string]interface{}{"id": "si_2", "price": "price_2", "quantity": 1, "metadata": map[string]interface{}{"seat": "b"}},
+	})
+
+	diff, err := r.Diff(context.Background(), state.State(), terraform.NewResourceConfigRaw(testSubscriptionItemsConfig(
+		map[string]interface{}{"price": "price_2", "quantity": 1},
+	)), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := schema.InternalMap(r.Schema).Data(state.State(), diff)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	items, diags := expandSubscriptionItems(d)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if len(items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(items))
+	}
+	if items[0].ID == nil || *items[0].ID != "si_2" || *items[0].Price != "price_2" {
+		t.Errorf("expected si_2 to be kept, got %v", items[0])
+	}
+	if items[0].Metadata["seat"] != "" {
+		t.Errorf("expected the removed metadata to be cleared, got %v", items[0].Metadata)
+	}
+	if items[1].ID == nil || *items[1].ID != "si_1" || items[1].Deleted == nil || !*items[1].Deleted {
+		t.Errorf("expected si_1 to be deleted, got %v", items[1])
+	}
+}
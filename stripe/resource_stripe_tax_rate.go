@@ -3,9 +3,12 @@ package stripe
 import (
 	"context"
 	"log"
+	"math"
+	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	stripe "github.com/stripe/stripe-go/v72"
 	"github.com/stripe/stripe-go/v72/client"
 )
@@ -17,7 +20,7 @@ func resourceStripeTaxRate() *schema.Resource {
 		UpdateContext: resourceStripeTaxRateUpdate,
 		DeleteContext: resourceStripeTaxRateDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateCheckingLivemode(resourceStripeTaxRateLivemode),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -25,6 +28,15 @@ func resourceStripeTaxRate() *schema.Resource {
 				Type:     schema.TypeBool,
 				Required: true,
 			},
+			"archive_on_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"country": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
 			"created": {
 				Type:     schema.TypeInt,
 				Computed: true,
@@ -37,9 +49,11 @@ func resourceStripeTaxRate() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			// Stripe doesn't allow changing the rate itself.
 			"inclusive": {
 				Type:     schema.TypeBool,
 				Required: true,
+				ForceNew: true,
 			},
 			"jurisdiction": {
 				Type:     schema.TypeString,
@@ -57,15 +71,42 @@ func resourceStripeTaxRate() *schema.Resource {
 				Optional: true,
 			},
 			"percentage": {
-				Type:     schema.TypeFloat,
-				Required: true,
+				Type:             schema.TypeFloat,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validation.FloatBetween(0, 100),
+				DiffSuppressFunc: suppressEquivalentPercentage,
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"tax_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateEnum("gst", "hst", "jct", "pst", "qst", "rst", "sales_tax", "vat"),
 			},
 		},
 	}
 }
 
+// Stripe stores percentages with 4 decimal places, anything smaller is noise.
+func suppressEquivalentPercentage(k, old, new string, d *schema.ResourceData) bool {
+	o, err := strconv.ParseFloat(old, 64)
+	if err != nil {
+		return false
+	}
+
+	n, err := strconv.ParseFloat(new, 64)
+	if err != nil {
+		return false
+	}
+
+	return math.Round(o*10000) == math.Round(n*10000)
+}
+
 func resourceStripeTaxRateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*client.API)
+	client := m.(*providerMeta).API
 	taxRateDisplayName := d.Get("display_name").(string)
 	taxRateInclusive := d.Get("inclusive").(bool)
 	taxRatePercentage := d.Get("percentage").(float64)
@@ -81,6 +122,10 @@ func resourceStripeTaxRateCreate(ctx context.Context, d *schema.ResourceData, m
 		params.Active = stripe.Bool(active.(bool))
 	}
 
+	if country, ok := d.GetOk("country"); ok {
+		params.Country = stripe.String(country.(string))
+	}
+
 	if description, ok := d.GetOk("description"); ok {
 		params.Description = stripe.String(description.(string))
 	}
@@ -89,8 +134,21 @@ func resourceStripeTaxRateCreate(ctx context.Context, d *schema.ResourceData, m
 		params.Jurisdiction = stripe.String(jurisdiction.(string))
 	}
 
-	params.Metadata = expandMetadata(d)
+	if state, ok := d.GetOk("state"); ok {
+		params.State = stripe.String(state.(string))
+	}
+
+	if taxType, ok := d.GetOk("tax_type"); ok {
+		params.TaxType = stripe.String(taxType.(string))
+	}
 
+	metadata, diags := expandMetadata(d)
+	if diags.HasError() {
+		return diags
+	}
+	params.Metadata = metadata
+
+	setIdempotencyKey(params, d)
 	tax, err := client.TaxRates.New(params)
 	if err != nil {
 		return diag.FromErr(err)
@@ -98,17 +156,24 @@ func resourceStripeTaxRateCreate(ctx context.Context, d *schema.ResourceData, m
 
 	log.Printf("[INFO] Create Tax Rate: %s (%f)", tax.ID, tax.Percentage)
 	d.SetId(tax.ID)
-	d.Set("display_name", tax.DisplayName)
-	d.Set("inclusive", tax.Inclusive)
-	d.Set("percentage", tax.Percentage)
-	d.Set("created", tax.Created)
-	d.Set("livemode", tax.Livemode)
 
-	return nil
+	return resourceStripeTaxRateRead(ctx, d, m)
+}
+
+func resourceStripeTaxRateLivemode(ctx context.Context, client *client.API, id string) (bool, error) {
+	params := &stripe.TaxRateParams{}
+	params.Context = ctx
+
+	tax, err := client.TaxRates.Get(id, params)
+	if err != nil {
+		return false, err
+	}
+
+	return tax.Livemode, nil
 }
 
 func resourceStripeTaxRateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*client.API)
+	client := m.(*providerMeta).API
 
 	params := &stripe.TaxRateParams{}
 	params.Context = ctx
@@ -119,6 +184,7 @@ func resourceStripeTaxRateRead(ctx context.Context, d *schema.ResourceData, m in
 	}
 
 	d.Set("active", tax.Active)
+	d.Set("country", tax.Country)
 	d.Set("created", tax.Created)
 	d.Set("description", tax.Description)
 	d.Set("display_name", tax.DisplayName)
@@ -126,12 +192,15 @@ func resourceStripeTaxRateRead(ctx context.Context, d *schema.ResourceData, m in
 	d.Set("jurisdiction", tax.Jurisdiction)
 	d.Set("livemode", tax.Livemode)
 	d.Set("metadata", tax.Metadata)
+	d.Set("percentage", tax.Percentage)
+	d.Set("state", tax.State)
+	d.Set("tax_type", tax.TaxType)
 
 	return nil
 }
 
 func resourceStripeTaxRateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*client.API)
+	client := m.(*providerMeta).API
 
 	params := &stripe.TaxRateParams{}
 	params.Context = ctx
@@ -140,11 +209,15 @@ func resourceStripeTaxRateUpdate(ctx context.Context, d *schema.ResourceData, m
 		params.Active = stripe.Bool(d.Get("active").(bool))
 	}
 
+	if d.HasChange("country") {
+		params.Country = stripe.String(d.Get("country").(string))
+	}
+
 	if d.HasChange("description") {
 		params.Description = stripe.String(d.Get("description").(string))
 	}
 
-	if d.HasChange("diplay_name") {
+	if d.HasChange("display_name") {
 		params.DisplayName = stripe.String(d.Get("display_name").(string))
 	}
 
@@ -153,7 +226,19 @@ func resourceStripeTaxRateUpdate(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	if d.HasChange("metadata") {
-		params.Metadata = expandMetadata(d)
+		metadata, diags := expandMetadata(d)
+		if diags.HasError() {
+			return diags
+		}
+		params.Metadata = metadata
+	}
+
+	if d.HasChange("state") {
+		params.State = stripe.String(d.Get("state").(string))
+	}
+
+	if d.HasChange("tax_type") {
+		params.TaxType = stripe.String(d.Get("tax_type").(string))
 	}
 
 	if _, err := client.TaxRates.Update(d.Id(), params); err != nil {
@@ -164,5 +249,21 @@ func resourceStripeTaxRateUpdate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceStripeTaxRateDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if d.Get("archive_on_destroy").(bool) {
+		client := m.(*providerMeta).API
+
+		params := &stripe.TaxRateParams{
+			Active: stripe.Bool(false),
+		}
+		params.Context = ctx
+
+		if _, err := client.TaxRates.Update(d.Id(), params); err != nil {
+			return diag.FromErr(err)
+		}
+
+		d.SetId("")
+		return nil
+	}
+
 	return diag.Errorf("[WARNING] Stripe doesn't allow deleting tax rates via the API.  Your state file contains at least one (\"%v\") that needs deletion.  Please remove it manually.", d.Get("display_name"))
 }
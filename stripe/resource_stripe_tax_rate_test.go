@@ -0,0 +1,146 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceStripeTaxRateUpdateDisplayName(t *testing.T) {
+	displayName := "VAT"
+	m := testProviderMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/tax_rates/txr_123" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+
+		if r.Method == http.MethodPost {
+			if err := r.ParseForm(); err != nil {
+				t.Fatal(err)
+			}
+			if v, ok := r.PostForm["display_name"]; ok {
+				displayName = v[0]
+			}
+		}
+
+		fmt.Fprintf(w, `{"id": "txr_123", "object": "tax_rate", "active": true, "display_name": %q, "inclusive": false, "percentage": 20}`, displayName)
+	})
+
+	r := resourceStripeTaxRate()
+	state := r.TestResourceData()
+	state.SetId("txr_123")
+	state.Set("active", true)
+	state.Set("display_name", "VAT")
+	state.Set("inclusive", false)
+	state.Set("percentage", 20.0)
+
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"active":       true,
+		"display_name": "Sales tax",
+		"inclusive":    false,
+		"percentage":   20.0,
+	})
+
+	diff, err := r.Diff(context.Background(), state.State(), config, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := schema.InternalMap(r.Schema).Data(state.State(), diff)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diags := r.UpdateContext(context.Background(), d, m); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if displayName != "Sales tax" {
+		t.Errorf("expected Stripe to receive the new display name, got %q", displayName)
+	}
+	if got := d.Get("display_name"); got != "Sales tax" {
+		t.Errorf("expected display_name to read back as %q, got %q", "Sales tax", got)
+	}
+}
+
+func TestResourceStripeTaxRatePercentageRoundTrip(t *testing.T) {
+	m := testProviderMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "txr_123", "object": "tax_rate", "active": true, "display_name": "Sales tax", "inclusive": false, "percentage": 8.875}`)
+	})
+
+	r := resourceStripeTaxRate()
+	d := r.TestResourceData()
+	d.SetId("txr_123")
+	d.Set("archive_on_destroy", true)
+
+	if diags := r.ReadContext(context.Background(), d, m); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"active":       true,
+		"display_name": "Sales tax",
+		"inclusive":    false,
+		"percentage":   8.875,
+	})
+
+	diff, err := r.Diff(context.Background(), d.State(), config, m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Errorf("expected no diff, got %#v", diff.Attributes)
+	}
+}
+
+func TestResourceStripeTaxRateReplacedOnRateChange(t *testing.T) {
+	r := resourceStripeTaxRate()
+	state := r.TestResourceData()
+	state.SetId("txr_123")
+	state.Set("active", true)
+	state.Set("display_name", "Sales tax")
+	state.Set("inclusive", false)
+	state.Set("percentage", 8.875)
+
+	for name, config := range map[string]map[string]interface{}{
+		"percentage": {"active": true, "display_name": "Sales tax", "inclusive": false, "percentage": 9.0},
+		"inclusive":  {"active": true, "display_name": "Sales tax", "inclusive": true, "percentage": 8.875},
+	} {
+		t.Run(name, func(t *testing.T) {
+			diff, err := r.Diff(context.Background(), state.State(), terraform.NewResourceConfigRaw(config), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff == nil || !diff.RequiresNew() {
+				t.Errorf("expected a replacement, got %#v", diff)
+			}
+		})
+	}
+}
+
+func TestResourceStripeTaxRateCreateReadsBack(t *testing.T) {
+	m := testProviderMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "txr_123", "object": "tax_rate", "active": true, "display_name": "VAT", "inclusive": false, "percentage": 20, "created": 1609459200, "livemode": true}`)
+	})
+
+	r := resourceStripeTaxRate()
+	d := testResourceCreateData(t, r, map[string]interface{}{
+		"display_name": "VAT",
+		"inclusive":    false,
+		"percentage":   20.0,
+	})
+
+	if diags := r.CreateContext(context.Background(), d, m); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if got := d.Get("created").(int); got != 1609459200 {
+		t.Errorf("expected created 1609459200, got %d", got)
+	}
+	if got := d.Get("livemode").(bool); !got {
+		t.Errorf("expected livemode to be read back")
+	}
+}
@@ -0,0 +1,309 @@
+package stripe
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	stripe "github.com/stripe/stripe-go/v72"
+	"github.com/stripe/stripe-go/v72/client"
+)
+
+// stripe-go only knows a fixed list of tipping currencies and lacks the name,
+// reboot window and Stripe S700 settings, so configurations are sent as extra
+// parameters and read from the raw response.
+func resourceStripeTerminalConfiguration() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceStripeTerminalConfigurationCreate,
+		ReadContext:   resourceStripeTerminalConfigurationRead,
+		UpdateContext: resourceStripeTerminalConfigurationUpdate,
+		DeleteContext: resourceStripeTerminalConfigurationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateCheckingLivemode(resourceStripeTerminalConfigurationLivemode),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"tipping": {
+				Type: schema.TypeSet,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"currency": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"fixed_amounts": {
+							Type:     schema.TypeList,
+							Elem:     &schema.Schema{Type: schema.TypeInt},
+							Optional: true,
+							MaxItems: 3,
+						},
+						"percentages": {
+							Type:     schema.TypeList,
+							Elem:     &schema.Schema{Type: schema.TypeInt},
+							Optional: true,
+							MaxItems: 3,
+						},
+						// Below this amount fixed amounts are offered, above it percentages.
+						"smart_tip_threshold": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+					},
+				},
+				Optional: true,
+			},
+			"bbpos_wisepos_e": terminalConfigurationDeviceSchema(),
+			"stripe_s700":     terminalConfigurationDeviceSchema(),
+			"reboot_window": {
+				Type: schema.TypeList,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"start_hour": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(0, 23),
+						},
+						"end_hour": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(0, 23),
+						},
+					},
+				},
+				MaxItems: 1,
+				Optional: true,
+			},
+			// Computed
+			"is_account_default": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"livemode": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func terminalConfigurationDeviceSchema() *schema.Schema {
+	return &schema.Schema{
+		Type: schema.TypeList,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				// ID of a file uploaded with the terminal_reader_splashscreen purpose.
+				"splashscreen": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+			},
+		},
+		MaxItems: 1,
+		Optional: true,
+	}
+}
+
+// terminalConfigurationJSON holds the settings decoded from the raw response.
+type terminalConfigurationJSON struct {
+	Name    string `json:"name"`
+	Tipping map[string]*struct {
+		FixedAmounts      []int64 `json:"fixed_amounts"`
+		Percentages       []int64 `json:"percentages"`
+		SmartTipThreshold int64   `json:"smart_tip_threshold"`
+	} `json:"tipping"`
+	BBPOSWisePOSE *terminalConfigurationDeviceJSON `json:"bbpos_wisepos_e"`
+	StripeS700    *terminalConfigurationDeviceJSON `json:"stripe_s700"`
+	RebootWindow  *struct {
+		StartHour int64 `json:"start_hour"`
+		EndHour   int64 `json:"end_hour"`
+	} `json:"reboot_window"`
+}
+
+type terminalConfigurationDeviceJSON struct {
+	Splashscreen string `json:"splashscreen"`
+}
+
+// expandTerminalConfiguration adds the settings that changed to params, all
+// of them when creating. Removed settings are unset with empty values.
+func expandTerminalConfiguration(d *schema.ResourceData, params *stripe.TerminalConfigurationParams) {
+	if d.HasChange("name") {
+		params.AddExtra("name", d.Get("name").(string))
+	}
+
+	if d.HasChange("tipping") {
+		o, n := d.GetChange("tipping")
+		configured := make(map[string]bool)
+		for _, v := range n.(*schema.Set).List() {
+			tipping := v.(map[string]interface{})
+			currency := strings.ToLower(tipping["currency"].(string))
+			configured[currency] = true
+
+			for i, amount := range tipping["fixed_amounts"].([]interface{}) {
+				params.AddExtra(fmt.Sprintf("tipping[%s][fixed_amounts][%d]", currency, i), strconv.Itoa(amount.(int)))
+			}
+			for i, percentage := range tipping["percentages"].([]interface{}) {
+				params.AddExtra(fmt.Sprintf("tipping[%s][percentages][%d]", currency, i), strconv.Itoa(percentage.(int)))
+			}
+			if threshold := tipping["smart_tip_threshold"].(int); threshold != 0 {
+				params.AddExtra(fmt.Sprintf("tipping[%s][smart_tip_threshold]", currency), strconv.Itoa(threshold))
+			}
+		}
+
+		for _, v := range o.(*schema.Set).List() {
+			currency := strings.ToLower(v.(map[string]interface{})["currency"].(string))
+			if !configured[currency] {
+				params.AddExtra(fmt.Sprintf("tipping[%s]", currency), "")
+			}
+		}
+	}
+
+	for _, device := range []string{"bbpos_wisepos_e", "stripe_s700"} {
+		if !d.HasChange(device) {
+			continue
+		}
+		if splashscreen, ok := d.GetOk(device + ".0.splashscreen"); ok {
+			params.AddExtra(device+"[splashscreen]", splashscreen.(string))
+		} else {
+			params.AddExtra(device, "")
+		}
+	}
+
+	if d.HasChange("reboot_window") {
+		if _, ok := d.GetOk("reboot_window"); ok {
+			params.AddExtra("reboot_window[start_hour]", strconv.Itoa(d.Get("reboot_window.0.start_hour").(int)))
+			params.AddExtra("reboot_window[end_hour]", strconv.Itoa(d.Get("reboot_window.0.end_hour").(int)))
+		} else {
+			params.AddExtra("reboot_window", "")
+		}
+	}
+}
+
+func flattenTerminalConfigurationDevice(in *terminalConfigurationDeviceJSON) []map[string]interface{} {
+	if in == nil || in.Splashscreen == "" {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{
+		{
+			"splashscreen": in.Splashscreen,
+		},
+	}
+}
+
+func resourceStripeTerminalConfigurationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.TerminalConfigurationParams{}
+	params.Context = ctx
+	expandTerminalConfiguration(d, params)
+
+	setIdempotencyKey(params, d)
+	configuration, err := client.TerminalConfigurations.New(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Create terminal configuration: %s", configuration.ID)
+	d.SetId(configuration.ID)
+
+	return resourceStripeTerminalConfigurationRead(ctx, d, m)
+}
+
+func resourceStripeTerminalConfigurationLivemode(ctx context.Context, client *client.API, id string) (bool, error) {
+	params := &stripe.TerminalConfigurationParams{}
+	params.Context = ctx
+
+	configuration, err := client.TerminalConfigurations.Get(id, params)
+	if err != nil {
+		return false, err
+	}
+
+	return configuration.Livemode, nil
+}
+
+func resourceStripeTerminalConfigurationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.TerminalConfigurationParams{}
+	params.Context = ctx
+
+	configuration, err := client.TerminalConfigurations.Get(d.Id(), params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var raw terminalConfigurationJSON
+	if err := json.Unmarshal(configuration.LastResponse.RawJSON, &raw); err != nil {
+		return diag.FromErr(err)
+	}
+
+	tipping := make([]map[string]interface{}, 0, len(raw.Tipping))
+	for currency, t := range raw.Tipping {
+		if t == nil {
+			continue
+		}
+		tipping = append(tipping, map[string]interface{}{
+			"currency":            currency,
+			"fixed_amounts":       t.FixedAmounts,
+			"percentages":         t.Percentages,
+			"smart_tip_threshold": t.SmartTipThreshold,
+		})
+	}
+
+	rebootWindow := []map[string]interface{}{}
+	if raw.RebootWindow != nil {
+		rebootWindow = append(rebootWindow, map[string]interface{}{
+			"start_hour": raw.RebootWindow.StartHour,
+			"end_hour":   raw.RebootWindow.EndHour,
+		})
+	}
+
+	d.Set("name", raw.Name)
+	d.Set("tipping", tipping)
+	d.Set("bbpos_wisepos_e", flattenTerminalConfigurationDevice(raw.BBPOSWisePOSE))
+	d.Set("stripe_s700", flattenTerminalConfigurationDevice(raw.StripeS700))
+	d.Set("reboot_window", rebootWindow)
+	d.Set("is_account_default", configuration.IsAccountDefault)
+	d.Set("livemode", configuration.Livemode)
+
+	return nil
+}
+
+func resourceStripeTerminalConfigurationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.TerminalConfigurationParams{}
+	params.Context = ctx
+	expandTerminalConfiguration(d, params)
+
+	if _, err := client.TerminalConfigurations.Update(d.Id(), params); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStripeTerminalConfigurationRead(ctx, d, m)
+}
+
+func resourceStripeTerminalConfigurationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.TerminalConfigurationParams{}
+	params.Context = ctx
+
+	if _, err := client.TerminalConfigurations.Del(d.Id(), params); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,184 @@
+package stripe
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	stripe "github.com/stripe/stripe-go/v72"
+	"github.com/stripe/stripe-go/v72/client"
+)
+
+func resourceStripeTerminalLocation() *schema.Resource {
+	// Stripe requires an address for every location.
+	address := addressSchema()
+	address.Optional = false
+	address.Required = true
+
+	return &schema.Resource{
+		CreateContext: resourceStripeTerminalLocationCreate,
+		ReadContext:   resourceStripeTerminalLocationRead,
+		UpdateContext: resourceStripeTerminalLocationUpdate,
+		DeleteContext: resourceStripeTerminalLocationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateCheckingLivemode(resourceStripeTerminalLocationLivemode),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"display_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"address": address,
+			"metadata": {
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional: true,
+			},
+			// Computed
+			"livemode": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func expandTerminalLocationAddress(in []interface{}) *stripe.AccountAddressParams {
+	address := expandAddress(in)
+	if address == nil {
+		return nil
+	}
+
+	return &stripe.AccountAddressParams{
+		Line1:      address.Line1,
+		Line2:      address.Line2,
+		City:       address.City,
+		State:      address.State,
+		PostalCode: address.PostalCode,
+		Country:    address.Country,
+	}
+}
+
+// flattenTerminalLocationAddress flattens addresses stripe-go decodes into
+// params, unlike the ones of other objects.
+func flattenTerminalLocationAddress(in *stripe.AccountAddressParams) []map[string]interface{} {
+	if in == nil {
+		return []map[string]interface{}{}
+	}
+
+	return flattenAddress(stripe.Address{
+		Line1:      stripe.StringValue(in.Line1),
+		Line2:      stripe.StringValue(in.Line2),
+		City:       stripe.StringValue(in.City),
+		State:      stripe.StringValue(in.State),
+		PostalCode: stripe.StringValue(in.PostalCode),
+		Country:    stripe.StringValue(in.Country),
+	})
+}
+
+func resourceStripeTerminalLocationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+	displayName := d.Get("display_name").(string)
+
+	params := &stripe.TerminalLocationParams{
+		DisplayName: stripe.String(displayName),
+		Address:     expandTerminalLocationAddress(d.Get("address").([]interface{})),
+	}
+	params.Context = ctx
+
+	metadata, diags := expandMetadata(d)
+	if diags.HasError() {
+		return diags
+	}
+	params.Metadata = metadata
+
+	setIdempotencyKey(params, d)
+	location, err := client.TerminalLocations.New(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Create terminal location: %s (%s)", location.ID, displayName)
+	d.SetId(location.ID)
+
+	return resourceStripeTerminalLocationRead(ctx, d, m)
+}
+
+func resourceStripeTerminalLocationLivemode(ctx context.Context, client *client.API, id string) (bool, error) {
+	params := &stripe.TerminalLocationParams{}
+	params.Context = ctx
+
+	location, err := client.TerminalLocations.Get(id, params)
+	if err != nil {
+		return false, err
+	}
+
+	return location.Livemode, nil
+}
+
+func resourceStripeTerminalLocationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.TerminalLocationParams{}
+	params.Context = ctx
+
+	location, err := client.TerminalLocations.Get(d.Id(), params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("display_name", location.DisplayName)
+	d.Set("address", flattenTerminalLocationAddress(location.Address))
+	d.Set("metadata", location.Metadata)
+	d.Set("livemode", location.Livemode)
+
+	return nil
+}
+
+func resourceStripeTerminalLocationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.TerminalLocationParams{}
+	params.Context = ctx
+
+	if d.HasChange("display_name") {
+		params.DisplayName = stripe.String(d.Get("display_name").(string))
+	}
+
+	if d.HasChange("address") {
+		params.Address = expandTerminalLocationAddress(d.Get("address").([]interface{}))
+	}
+
+	if d.HasChange("metadata") {
+		metadata, diags := expandMetadata(d)
+		if diags.HasError() {
+			return diags
+		}
+		params.Metadata = metadata
+	}
+
+	if _, err := client.TerminalLocations.Update(d.Id(), params); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStripeTerminalLocationRead(ctx, d, m)
+}
+
+func resourceStripeTerminalLocationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.TerminalLocationParams{}
+	params.Context = ctx
+
+	if _, err := client.TerminalLocations.Del(d.Id(), params); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,177 @@
+package stripe
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	stripe "github.com/stripe/stripe-go/v72"
+	"github.com/stripe/stripe-go/v72/client"
+)
+
+func resourceStripeTerminalReader() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceStripeTerminalReaderCreate,
+		ReadContext:   resourceStripeTerminalReaderRead,
+		UpdateContext: resourceStripeTerminalReaderUpdate,
+		DeleteContext: resourceStripeTerminalReaderDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateCheckingLivemode(resourceStripeTerminalReaderLivemode),
+		},
+
+		Schema: map[string]*schema.Schema{
+			// Readers can't be moved, they have to be registered again.
+			"location": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			// Only used to register the reader, it can't be read back.
+			"registration_code": {
+				Type:      schema.TypeString,
+				Required:  true,
+				ForceNew:  true,
+				Sensitive: true,
+			},
+			"label": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"metadata": {
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional: true,
+			},
+			// Computed
+			"device_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"serial_number": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"livemode": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceStripeTerminalReaderCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.TerminalReaderParams{
+		RegistrationCode: stripe.String(d.Get("registration_code").(string)),
+	}
+	params.Context = ctx
+
+	if location, ok := d.GetOk("location"); ok {
+		params.Location = stripe.String(location.(string))
+	}
+
+	if label, ok := d.GetOk("label"); ok {
+		params.Label = stripe.String(label.(string))
+	}
+
+	metadata, diags := expandMetadata(d)
+	if diags.HasError() {
+		return diags
+	}
+	params.Metadata = metadata
+
+	setIdempotencyKey(params, d)
+	reader, err := client.TerminalReaders.New(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Create terminal reader: %s (%s)", reader.ID, reader.SerialNumber)
+	d.SetId(reader.ID)
+
+	return resourceStripeTerminalReaderRead(ctx, d, m)
+}
+
+func resourceStripeTerminalReaderLivemode(ctx context.Context, client *client.API, id string) (bool, error) {
+	params := &stripe.TerminalReaderGetParams{}
+	params.Context = ctx
+
+	reader, err := client.TerminalReaders.Get(id, params)
+	if err != nil {
+		return false, err
+	}
+
+	return reader.Livemode, nil
+}
+
+func resourceStripeTerminalReaderRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.TerminalReaderGetParams{}
+	params.Context = ctx
+
+	reader, err := client.TerminalReaders.Get(d.Id(), params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("location", reader.Location)
+	d.Set("label", reader.Label)
+	d.Set("metadata", reader.Metadata)
+	d.Set("device_type", reader.DeviceType)
+	d.Set("serial_number", reader.SerialNumber)
+	d.Set("status", reader.Status)
+	d.Set("livemode", reader.Livemode)
+
+	return nil
+}
+
+func resourceStripeTerminalReaderUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.TerminalReaderParams{}
+	params.Context = ctx
+
+	if d.HasChange("label") {
+		params.Label = stripe.String(d.Get("label").(string))
+	}
+
+	if d.HasChange("metadata") {
+		metadata, diags := expandMetadata(d)
+		if diags.HasError() {
+			return diags
+		}
+		params.Metadata = metadata
+	}
+
+	if _, err := client.TerminalReaders.Update(d.Id(), params); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStripeTerminalReaderRead(ctx, d, m)
+}
+
+func resourceStripeTerminalReaderDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).API
+
+	params := &stripe.TerminalReaderParams{}
+	params.Context = ctx
+
+	if _, err := client.TerminalReaders.Del(d.Id(), params); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
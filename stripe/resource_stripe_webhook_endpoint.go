@@ -2,6 +2,7 @@ package stripe
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -18,9 +19,11 @@ func resourceStripeWebhookEndpoint() *schema.Resource {
 		UpdateContext: resourceStripeWebhookEndpointUpdate,
 		DeleteContext: resourceStripeWebhookEndpointDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateCheckingLivemode(resourceStripeWebhookEndpointLivemode),
 		},
 
+		CustomizeDiff: resourceStripeWebhookEndpointCustomizeDiff,
+
 		Schema: map[string]*schema.Schema{
 			"url": {
 				Type:     schema.TypeString,
@@ -31,11 +34,37 @@ func resourceStripeWebhookEndpoint() *schema.Resource {
 				Elem:     &schema.Schema{Type: schema.TypeString},
 				Required: true,
 			},
+			"enabled_events_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
 			"connect": {
 				Type:     schema.TypeBool,
 				Optional: true,
 				ForceNew: true,
 			},
+			"api_version": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"disabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"metadata": {
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional: true,
+			},
 			"secret": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -44,8 +73,25 @@ func resourceStripeWebhookEndpoint() *schema.Resource {
 	}
 }
 
+// The "*" event enables every event type and must be the only element of
+// enabled_events, otherwise Stripe rejects the request at apply time.
+func resourceStripeWebhookEndpointCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	events := d.Get("enabled_events").([]interface{})
+	for _, event := range events {
+		if event == "*" && len(events) > 1 {
+			return fmt.Errorf("enabled_events: \"*\" enables all events and can't be combined with other events")
+		}
+	}
+
+	if d.HasChange("enabled_events") {
+		d.SetNewComputed("enabled_events_count")
+	}
+
+	return nil
+}
+
 func resourceStripeWebhookEndpointCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*client.API)
+	client := m.(*providerMeta).API
 	url := d.Get("url").(string)
 
 	params := &stripe.WebhookEndpointParams{
@@ -58,6 +104,21 @@ func resourceStripeWebhookEndpointCreate(ctx context.Context, d *schema.Resource
 		params.Connect = stripe.Bool(connect.(bool))
 	}
 
+	if apiVersion, ok := d.GetOk("api_version"); ok {
+		params.APIVersion = stripe.String(apiVersion.(string))
+	}
+
+	if description, ok := d.GetOk("description"); ok {
+		params.Description = stripe.String(description.(string))
+	}
+
+	metadata, diags := expandMetadata(d)
+	if diags.HasError() {
+		return diags
+	}
+	params.Metadata = metadata
+
+	setIdempotencyKey(params, d)
 	webhookEndpoint, err := client.WebhookEndpoints.New(params)
 	if err != nil {
 		return diag.FromErr(err)
@@ -65,13 +126,38 @@ func resourceStripeWebhookEndpointCreate(ctx context.Context, d *schema.Resource
 
 	log.Printf("[INFO] Create webbook endpoint: %s", url)
 	d.SetId(webhookEndpoint.ID)
+	// The secret is only returned on creation, it can't be read back later.
 	d.Set("secret", webhookEndpoint.Secret)
 
-	return nil
+	// Endpoints can only be disabled once they exist.
+	if d.Get("disabled").(bool) {
+		params := &stripe.WebhookEndpointParams{
+			Disabled: stripe.Bool(true),
+		}
+		params.Context = ctx
+
+		if _, err := client.WebhookEndpoints.Update(d.Id(), params); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceStripeWebhookEndpointRead(ctx, d, m)
+}
+
+func resourceStripeWebhookEndpointLivemode(ctx context.Context, client *client.API, id string) (bool, error) {
+	params := &stripe.WebhookEndpointParams{}
+	params.Context = ctx
+
+	webhookEndpoint, err := client.WebhookEndpoints.Get(id, params)
+	if err != nil {
+		return false, err
+	}
+
+	return webhookEndpoint.Livemode, nil
 }
 
 func resourceStripeWebhookEndpointRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*client.API)
+	client := m.(*providerMeta).API
 
 	params := &stripe.WebhookEndpointParams{}
 	params.Context = ctx
@@ -83,13 +169,18 @@ func resourceStripeWebhookEndpointRead(ctx context.Context, d *schema.ResourceDa
 
 	d.Set("url", webhookEndpoint.URL)
 	d.Set("enabled_events", webhookEndpoint.EnabledEvents)
+	d.Set("enabled_events_count", len(webhookEndpoint.EnabledEvents))
 	d.Set("connect", webhookEndpoint.Application != "")
+	d.Set("api_version", webhookEndpoint.APIVersion)
+	d.Set("description", webhookEndpoint.Description)
+	d.Set("disabled", webhookEndpoint.Status == "disabled")
+	d.Set("metadata", webhookEndpoint.Metadata)
 
 	return nil
 }
 
 func resourceStripeWebhookEndpointUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*client.API)
+	client := m.(*providerMeta).API
 
 	params := &stripe.WebhookEndpointParams{}
 	params.Context = ctx
@@ -106,6 +197,22 @@ func resourceStripeWebhookEndpointUpdate(ctx context.Context, d *schema.Resource
 		params.Connect = stripe.Bool(d.Get("connect").(bool))
 	}
 
+	if d.HasChange("description") {
+		params.Description = stripe.String(d.Get("description").(string))
+	}
+
+	if d.HasChange("metadata") {
+		metadata, diags := expandMetadata(d)
+		if diags.HasError() {
+			return diags
+		}
+		params.Metadata = metadata
+	}
+
+	if d.HasChange("disabled") {
+		params.Disabled = stripe.Bool(d.Get("disabled").(bool))
+	}
+
 	if _, err := client.WebhookEndpoints.Update(d.Id(), params); err != nil {
 		return diag.FromErr(err)
 	}
@@ -114,7 +221,7 @@ func resourceStripeWebhookEndpointUpdate(ctx context.Context, d *schema.Resource
 }
 
 func resourceStripeWebhookEndpointDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*client.API)
+	client := m.(*providerMeta).API
 
 	params := &stripe.WebhookEndpointParams{}
 	params.Context = ctx
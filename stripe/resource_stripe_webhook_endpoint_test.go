@@ -0,0 +1,61 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceStripeWebhookEndpointWildcardRoundTrip(t *testing.T) {
+	m := testProviderMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			if err := r.ParseForm(); err != nil {
+				t.Fatal(err)
+			}
+			if got := r.PostForm.Get("enabled_events[0]"); got != "*" {
+				t.Errorf("expected the wildcard to be sent, got %q", got)
+			}
+		}
+		fmt.Fprint(w, `{"id": "we_123", "object": "webhook_endpoint", "url": "https://example.com/hooks", "enabled_events": ["*"], "api_version": "2020-08-27", "status": "enabled", "secret": "whsec_123"}`)
+	})
+
+	config := map[string]interface{}{
+		"url":            "https://example.com/hooks",
+		"enabled_events": []interface{}{"*"},
+	}
+
+	r := resourceStripeWebhookEndpoint()
+	d := testResourceCreateData(t, r, config)
+
+	if diags := r.CreateContext(context.Background(), d, m); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if got := d.Get("enabled_events_count").(int); got != 1 {
+		t.Errorf("expected enabled_events_count 1, got %d", got)
+	}
+
+	diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !diff.Empty() {
+		t.Errorf("expected no diff, got %v", diff.Attributes)
+	}
+}
+
+func TestResourceStripeWebhookEndpointWildcardWithOtherEvents(t *testing.T) {
+	r := resourceStripeWebhookEndpoint()
+
+	_, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"url":            "https://example.com/hooks",
+		"enabled_events": []interface{}{"*", "charge.succeeded"},
+	}), nil)
+	if err == nil || !strings.Contains(err.Error(), "can't be combined with other events") {
+		t.Errorf("expected the wildcard to be refused with other events, got %v", err)
+	}
+}
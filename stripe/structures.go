@@ -1,32 +1,201 @@
 package stripe
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	stripe "github.com/stripe/stripe-go/v72"
+	"github.com/stripe/stripe-go/v72/client"
 )
 
-func expandStringMap(m map[string]interface{}) map[string]string {
-	result := make(map[string]string)
+// importStateCheckingLivemode imports resources by ID, refusing objects that
+// live in another mode than the configured API token, as every subsequent
+// operation on them would fail.
+func importStateCheckingLivemode(livemode func(context.Context, *client.API, string) (bool, error)) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+		client := m.(*providerMeta).API
+
+		objectLivemode, err := livemode(ctx, client, d.Id())
+		if err != nil {
+			return nil, err
+		}
+
+		keyLivemode, err := tokenLivemode(ctx, client)
+		if err != nil {
+			return nil, err
+		}
+
+		if objectLivemode != keyLivemode {
+			return nil, fmt.Errorf("%s is a %s object but the Stripe API token is a %s key", d.Id(), modeName(objectLivemode), modeName(keyLivemode))
+		}
+
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
+// importStateChildOf imports resources only reachable through a parent
+// object, from "<parent ID>/<ID>" import IDs, checking their mode like
+// importStateCheckingLivemode.
+func importStateChildOf(parent string, livemode func(context.Context, *client.API, string, string) (bool, error)) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+		parts := strings.SplitN(d.Id(), "/", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("unexpected import ID %q, expected <%s ID>/<ID>", d.Id(), parent)
+		}
+
+		d.Set(parent, parts[0])
+		d.SetId(parts[1])
+
+		return importStateCheckingLivemode(func(ctx context.Context, client *client.API, id string) (bool, error) {
+			return livemode(ctx, client, parts[0], id)
+		})(ctx, d, m)
+	}
+}
+
+// withIdempotencyKey adds the computed idempotency_key a resource's create is
+// sent with. Terraform doesn't tell providers the address of a resource, so
+// the key is planned once per resource instance instead: identical resources
+// get different keys, and replacing one plans a new key.
+func withIdempotencyKey(r *schema.Resource) *schema.Resource {
+	r.Schema["idempotency_key"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+
+	customizeDiff := r.CustomizeDiff
+	r.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		// Resources being replaced are diffed again without their ID.
+		if meta, ok := m.(*providerMeta); ok && meta.useIdempotencyKeys && d.Id() == "" {
+			key, err := uuid.GenerateUUID()
+			if err != nil {
+				return err
+			}
+			if err := d.SetNew("idempotency_key", key); err != nil {
+				return err
+			}
+		}
+
+		if customizeDiff != nil {
+			return customizeDiff(ctx, d, m)
+		}
+		return nil
+	}
+
+	return r
+}
+
+// setIdempotencyKey sends a create with the key planned by withIdempotencyKey,
+// so that retrying it returns the object Stripe already created instead of
+// duplicating it. Nothing is sent when use_idempotency_keys is off.
+func setIdempotencyKey(params stripe.ParamsContainer, d *schema.ResourceData) {
+	if key, _ := d.Get("idempotency_key").(string); key != "" {
+		params.GetParams().SetIdempotencyKey("terraform-" + key)
+	}
+}
+
+// expandStringMap converts a Terraform map to a string map, returning an
+// error naming the first entry that isn't a string rather than panicking on
+// it. A nil map converts to an empty one.
+func expandStringMap(m map[string]interface{}) (map[string]string, error) {
+	result := make(map[string]string, len(m))
 	for k, v := range m {
-		result[k] = v.(string)
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s: expected a string value, got %T", k, v)
+		}
+		result[k] = s
 	}
-	return result
+	return result, nil
 }
 
-func expandMetadata(d *schema.ResourceData) map[string]string {
+func expandMetadata(d *schema.ResourceData) (map[string]string, diag.Diagnostics) {
 	old, new := d.GetChange("metadata")
+	oldMap, _ := old.(map[string]interface{})
+	newMap, _ := new.(map[string]interface{})
 
 	// Set the old values to empty string so that they can be removed
-	expanded := expandStringMap(old.(map[string]interface{}))
+	expanded, err := expandStringMap(oldMap)
+	if err != nil {
+		return nil, diag.Errorf("metadata: %s", err)
+	}
 	for key := range expanded {
 		expanded[key] = ""
 	}
 
 	// Add entries for the new/updated fields defined in Terraform
-	for key, value := range expandStringMap(new.(map[string]interface{})) {
+	configured, err := expandStringMap(newMap)
+	if err != nil {
+		return nil, diag.Errorf("metadata: %s", err)
+	}
+	for key, value := range configured {
 		expanded[key] = value
 	}
 
-	return expanded
+	return expanded, nil
+}
+
+// suppressEquivalentMetadata hides diffs caused by Stripe dropping metadata
+// entries whose value is empty, which is how it represents unset keys.
+func suppressEquivalentMetadata(k, old, new string, d *schema.ResourceData) bool {
+	if strings.HasSuffix(k, ".%") {
+		o, n := d.GetChange("metadata")
+		oldMap, _ := o.(map[string]interface{})
+		newMap, _ := n.(map[string]interface{})
+
+		// Malformed values are reported when expanding the metadata.
+		oldMetadata, err := expandStringMap(oldMap)
+		if err != nil {
+			return false
+		}
+		newMetadata, err := expandStringMap(newMap)
+		if err != nil {
+			return false
+		}
+
+		return reflect.DeepEqual(withoutEmptyValues(oldMetadata), withoutEmptyValues(newMetadata))
+	}
+
+	return old == "" && new == ""
+}
+
+// suppressFinalizeUnlessDraft ignores finalize changes on invoices and quotes
+// that aren't drafts anymore, there is nothing left to apply.
+func suppressFinalizeUnlessDraft(k, old, new string, d *schema.ResourceData) bool {
+	status := d.Get("status").(string)
+	return d.Id() != "" && status != "" && status != "draft"
+}
+
+// suppressEquivalentTime ignores differences between two RFC3339 timestamps
+// describing the same instant, e.g. in different time zones.
+func suppressEquivalentTime(k, old, new string, d *schema.ResourceData) bool {
+	oldTime, err := time.Parse(time.RFC3339, old)
+	if err != nil {
+		return false
+	}
+
+	newTime, err := time.Parse(time.RFC3339, new)
+	if err != nil {
+		return false
+	}
+
+	return oldTime.Equal(newTime)
+}
+
+func withoutEmptyValues(m map[string]string) map[string]string {
+	result := make(map[string]string)
+	for k, v := range m {
+		if v != "" {
+			result[k] = v
+		}
+	}
+	return result
 }
 
 func expandStringList(d *schema.ResourceData, key string) []*string {
@@ -46,10 +215,120 @@ func expandStringList(d *schema.ResourceData, key string) []*string {
 	return nil
 }
 
-func getMapKeys(m map[string]bool) []string {
-	keys := make([]string, 0, len(m))
-	for k := range m {
-		keys = append(keys, k)
+// isExpansionError reports whether Stripe refused to expand a field (e.g. a
+// restricted key lacking access to the expanded object), as opposed to the
+// object itself being unreachable or the request being invalid otherwise.
+func isExpansionError(err error) bool {
+	stripeErr, ok := err.(*stripe.Error)
+	if !ok {
+		return false
+	}
+
+	if stripeErr.HTTPStatusCode != http.StatusBadRequest && stripeErr.HTTPStatusCode != http.StatusForbidden {
+		return false
+	}
+
+	return strings.HasPrefix(stripeErr.Param, "expand") ||
+		strings.Contains(strings.ToLower(stripeErr.Msg), "expand")
+}
+
+func addressSchema() *schema.Schema {
+	return &schema.Schema{
+		Type: schema.TypeList,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"line1": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"line2": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"city": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"state": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"postal_code": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				// Stripe uppercases country codes.
+				"country": {
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateFunc:     validateCountry,
+					DiffSuppressFunc: suppressCaseDiff,
+				},
+			},
+		},
+		MaxItems:         1,
+		Optional:         true,
+		DiffSuppressFunc: suppressEmptyAddress,
+	}
+}
+
+func suppressCaseDiff(k, old, new string, d *schema.ResourceData) bool {
+	return strings.EqualFold(old, new)
+}
+
+// suppressEmptyAddress treats an address block without any value like no
+// address at all, which is how Stripe stores it.
+func suppressEmptyAddress(k, old, new string, d *schema.ResourceData) bool {
+	if !strings.HasSuffix(k, ".#") {
+		return false
+	}
+
+	o, n := d.GetChange(strings.TrimSuffix(k, ".#"))
+	return isEmptyAddress(o.([]interface{})) && isEmptyAddress(n.([]interface{}))
+}
+
+func isEmptyAddress(in []interface{}) bool {
+	if len(in) == 0 || in[0] == nil {
+		return true
+	}
+
+	for _, v := range in[0].(map[string]interface{}) {
+		if v.(string) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func expandAddress(in []interface{}) *stripe.AddressParams {
+	if len(in) == 0 || in[0] == nil {
+		return nil
+	}
+
+	address := in[0].(map[string]interface{})
+	return &stripe.AddressParams{
+		Line1:      stripe.String(address["line1"].(string)),
+		Line2:      stripe.String(address["line2"].(string)),
+		City:       stripe.String(address["city"].(string)),
+		State:      stripe.String(address["state"].(string)),
+		PostalCode: stripe.String(address["postal_code"].(string)),
+		Country:    stripe.String(address["country"].(string)),
+	}
+}
+
+func flattenAddress(in stripe.Address) []map[string]interface{} {
+	if in == (stripe.Address{}) {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{
+		{
+			"line1":       in.Line1,
+			"line2":       in.Line2,
+			"city":        in.City,
+			"state":       in.State,
+			"postal_code": in.PostalCode,
+			"country":     in.Country,
+		},
 	}
-	return keys
 }
@@ -0,0 +1,337 @@
+package stripe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+func TestAddressSchemaReadBack(t *testing.T) {
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"address": addressSchema(),
+		},
+	}
+
+	cases := []struct {
+		name   string
+		config map[string]interface{}
+		stored stripe.Address
+	}{
+		{
+			name: "lowercase country",
+			config: map[string]interface{}{
+				"address": []interface{}{map[string]interface{}{"line1": "1 Main St", "country": "us"}},
+			},
+			stored: stripe.Address{Line1: "1 Main St", Country: "US"},
+		},
+		{
+			name: "empty block",
+			config: map[string]interface{}{
+				"address": []interface{}{map[string]interface{}{}},
+			},
+		},
+		{
+			name: "empty values",
+			config: map[string]interface{}{
+				"address": []interface{}{map[string]interface{}{"line1": "", "city": ""}},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := r.TestResourceData()
+			d.SetId("cus_test")
+			if err := d.Set("address", flattenAddress(tc.stored)); err != nil {
+				t.Fatal(err)
+			}
+
+			diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(tc.config), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff != nil && !diff.Empty() {
+				t.Errorf("expected no diff, got %#v", diff.Attributes)
+			}
+		})
+	}
+}
+
+func TestValidateCountry(t *testing.T) {
+	for country, valid := range map[string]bool{
+		"US":  true,
+		"fr":  true,
+		"USA": false,
+		"U":   false,
+		"1A":  false,
+	} {
+		_, errs := validateCountry(country, "country")
+		if (len(errs) == 0) != valid {
+			t.Errorf("validateCountry(%q): expected valid=%t, got %v", country, valid, errs)
+		}
+	}
+}
+
+func TestIsExpansionError(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "expand param",
+			err:  &stripe.Error{HTTPStatusCode: http.StatusBadRequest, Param: "expand[0]", Msg: "This property cannot be expanded (tiers)."},
+			want: true,
+		},
+		{
+			name: "restricted key",
+			err:  &stripe.Error{HTTPStatusCode: http.StatusForbidden, Msg: "The provided key does not have the required permissions to expand currency_options."},
+			want: true,
+		},
+		{
+			name: "other invalid param",
+			err:  &stripe.Error{HTTPStatusCode: http.StatusBadRequest, Param: "currency", Msg: "Invalid currency: xyz"},
+			want: false,
+		},
+		{
+			name: "forbidden object",
+			err:  &stripe.Error{HTTPStatusCode: http.StatusForbidden, Msg: "The provided key does not have access to this price."},
+			want: false,
+		},
+		{
+			name: "not found",
+			err:  &stripe.Error{HTTPStatusCode: http.StatusNotFound, Param: "id", Msg: "No such price: 'price_123'"},
+			want: false,
+		},
+		{
+			name: "not a stripe error",
+			err:  errors.New("expand failed"),
+			want: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isExpansionError(tc.err); got != tc.want {
+				t.Errorf("expected %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestExpandStringMap(t *testing.T) {
+	cases := []struct {
+		name string
+		in   map[string]interface{}
+		want map[string]string
+		err  string
+	}{
+		{
+			name: "nil",
+			want: map[string]string{},
+		},
+		{
+			name: "empty",
+			in:   map[string]interface{}{},
+			want: map[string]string{},
+		},
+		{
+			name: "strings",
+			in:   map[string]interface{}{"interval": "month", "usage_type": ""},
+			want: map[string]string{"interval": "month", "usage_type": ""},
+		},
+		{
+			name: "integer",
+			in:   map[string]interface{}{"interval": "month", "interval_count": 3},
+			err:  "interval_count: expected a string value, got int",
+		},
+		{
+			name: "nil value",
+			in:   map[string]interface{}{"interval": nil},
+			err:  "interval: expected a string value, got <nil>",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := expandStringMap(tc.in)
+			if tc.err != "" {
+				if err == nil || err.Error() != tc.err {
+					t.Fatalf("expected error %q, got %v", tc.err, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestExpandMetadata(t *testing.T) {
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"metadata": {
+				Type:     schema.TypeMap,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Optional: true,
+			},
+		},
+	}
+
+	cases := []struct {
+		name string
+		old  map[string]interface{}
+		new  map[string]interface{}
+		want map[string]string
+	}{
+		{
+			name: "none",
+			want: map[string]string{},
+		},
+		{
+			name: "added",
+			new:  map[string]interface{}{"team": "billing"},
+			want: map[string]string{"team": "billing"},
+		},
+		{
+			name: "removed and updated",
+			old:  map[string]interface{}{"team": "billing", "owner": "alice"},
+			new:  map[string]interface{}{"team": "growth"},
+			want: map[string]string{"team": "growth", "owner": ""},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			state := r.TestResourceData()
+			state.SetId("test")
+			state.Set("metadata", tc.old)
+
+			config := map[string]interface{}{}
+			if tc.new != nil {
+				config["metadata"] = tc.new
+			}
+
+			diff, err := r.Diff(context.Background(), state.State(), terraform.NewResourceConfigRaw(config), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			d, err := schema.InternalMap(r.Schema).Data(state.State(), diff)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, diags := expandMetadata(d)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+// testLivemodeMeta serves objects in objectLivemode to a key in keyLivemode.
+func testLivemodeMeta(t *testing.T, objectLivemode, keyLivemode bool) *providerMeta {
+	return testProviderMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/balance":
+			fmt.Fprintf(w, `{"object": "balance", "livemode": %t}`, keyLivemode)
+		case "/v1/coupons/SUMMER":
+			fmt.Fprintf(w, `{"id": "SUMMER", "object": "coupon", "livemode": %t}`, objectLivemode)
+		case "/v1/customers/cus_123/tax_ids/txi_123":
+			fmt.Fprintf(w, `{"id": "txi_123", "object": "tax_id", "livemode": %t}`, objectLivemode)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
+
+func TestImportStateCheckingLivemode(t *testing.T) {
+	cases := []struct {
+		name           string
+		objectLivemode bool
+		keyLivemode    bool
+		err            string
+	}{
+		{
+			name: "test object with a test key",
+		},
+		{
+			name:           "live object with a live key",
+			objectLivemode: true,
+			keyLivemode:    true,
+		},
+		{
+			name:           "live object with a test key",
+			objectLivemode: true,
+			err:            "SUMMER is a live mode object but the Stripe API token is a test mode key",
+		},
+		{
+			name:        "test object with a live key",
+			keyLivemode: true,
+			err:         "SUMMER is a test mode object but the Stripe API token is a live mode key",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := resourceStripeCoupon()
+			d := r.TestResourceData()
+			d.SetId("SUMMER")
+
+			_, err := r.Importer.StateContext(context.Background(), d, testLivemodeMeta(t, tc.objectLivemode, tc.keyLivemode))
+			if tc.err == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.err {
+				t.Errorf("expected error %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestImportStateChildOf(t *testing.T) {
+	r := resourceStripeCustomerTaxID()
+
+	d := r.TestResourceData()
+	d.SetId("cus_123/txi_123")
+	if _, err := r.Importer.StateContext(context.Background(), d, testLivemodeMeta(t, false, false)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if d.Id() != "txi_123" || d.Get("customer") != "cus_123" {
+		t.Errorf("expected txi_123 of cus_123, got %s of %v", d.Id(), d.Get("customer"))
+	}
+
+	d = r.TestResourceData()
+	d.SetId("cus_123/txi_123")
+	if _, err := r.Importer.StateContext(context.Background(), d, testLivemodeMeta(t, true, false)); err == nil || !strings.Contains(err.Error(), "live mode object") {
+		t.Errorf("expected the live mode tax ID to be refused, got %v", err)
+	}
+
+	d = r.TestResourceData()
+	d.SetId("txi_123")
+	if _, err := r.Importer.StateContext(context.Background(), d, testLivemodeMeta(t, false, false)); err == nil || !strings.Contains(err.Error(), "expected <customer ID>/<ID>") {
+		t.Errorf("expected the import ID to be refused, got %v", err)
+	}
+}
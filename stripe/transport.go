@@ -0,0 +1,148 @@
+package stripe
+
+import (
+	"log"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	minRateLimitDelay = 500 * time.Millisecond
+	maxRateLimitDelay = 5 * time.Second
+)
+
+// headerTransport overrides headers of every request, including the ones
+// stripe-go always sets like Stripe-Version.
+type headerTransport struct {
+	headers http.Header
+	next    http.RoundTripper
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for key, values := range t.headers {
+		req.Header[key] = values
+	}
+
+	return t.next.RoundTrip(req)
+}
+
+// rateLimitTransport paces requests to Stripe and retries the ones its rate
+// limiter rejected, which stripe-go leaves to the caller. Other failures are
+// still retried by stripe-go itself.
+type rateLimitTransport struct {
+	maxRetries int64
+	interval   time.Duration
+	next       http.RoundTripper
+
+	mu       sync.Mutex
+	nextSlot time.Time
+}
+
+func rateLimitInterval(requestsPerSecond int) time.Duration {
+	if requestsPerSecond <= 0 {
+		return 0
+	}
+	return time.Second / time.Duration(requestsPerSecond)
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	for attempt := int64(0); ; attempt++ {
+		if err := sleepContext(req, t.reserve()); err != nil {
+			return nil, err
+		}
+
+		resp, err := t.next.RoundTrip(req)
+		if err != nil || !t.shouldRetry(req, resp, attempt) {
+			return resp, err
+		}
+
+		delay := rateLimitDelay(resp, attempt)
+		resp.Body.Close()
+		log.Printf("[WARN] Stripe rate limited %s %s, retrying in %s", req.Method, req.URL.Path, delay)
+
+		if err := sleepContext(req, delay); err != nil {
+			return nil, err
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(ctx)
+			req.Body = body
+		}
+	}
+}
+
+// reserve books the next request slot and tells how long to wait for it.
+func (t *rateLimitTransport) reserve() time.Duration {
+	if t.interval == 0 {
+		return 0
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	if t.nextSlot.Before(now) {
+		t.nextSlot = now
+	}
+	wait := t.nextSlot.Sub(now)
+	t.nextSlot = t.nextSlot.Add(t.interval)
+
+	return wait
+}
+
+func (t *rateLimitTransport) shouldRetry(req *http.Request, resp *http.Response, attempt int64) bool {
+	if resp.StatusCode != http.StatusTooManyRequests || attempt >= t.maxRetries {
+		return false
+	}
+
+	// Requests whose body can't be sent again can't be retried.
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	// Stripe tells whether the request may be retried for other 429s, like
+	// lock timeouts, and stripe-go already follows it.
+	return resp.Header.Get("Stripe-Should-Retry") == ""
+}
+
+// rateLimitDelay honors Retry-After when Stripe sends it, and otherwise
+// backs off exponentially with some jitter.
+func rateLimitDelay(resp *http.Response, attempt int64) time.Duration {
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	delay := minRateLimitDelay << uint(attempt)
+	if delay <= 0 || delay > maxRateLimitDelay {
+		delay = maxRateLimitDelay
+	}
+
+	// Keep between 75% and 100% of the delay so concurrent requests spread.
+	return delay - time.Duration(rand.Int63n(int64(delay/4)))
+}
+
+func sleepContext(req *http.Request, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-req.Context().Done():
+		return req.Context().Err()
+	}
+}
@@ -0,0 +1,196 @@
+package stripe
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHeaderTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Stripe-Version"); got != "2022-11-15" {
+			t.Errorf("expected Stripe-Version 2022-11-15, got %q", got)
+		}
+		if got := r.Header.Get("Stripe-Account"); got != "acct_123" {
+			t.Errorf("expected Stripe-Account acct_123, got %q", got)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer sk_test_123" {
+			t.Errorf("expected the other headers to be kept, got Authorization %q", got)
+		}
+	}))
+	defer server.Close()
+
+	headers := http.Header{}
+	headers.Set("Stripe-Version", "2022-11-15")
+	headers.Set("Stripe-Account", "acct_123")
+	client := &http.Client{Transport: &headerTransport{headers: headers, next: http.DefaultTransport}}
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Stripe-Version", "2020-08-27")
+	req.Header.Set("Authorization", "Bearer sk_test_123")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if got := req.Header.Get("Stripe-Version"); got != "2020-08-27" {
+		t.Errorf("expected the original request to be left untouched, got Stripe-Version %q", got)
+	}
+}
+
+func TestRateLimitTransportRetries(t *testing.T) {
+	bodies := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &rateLimitTransport{maxRetries: 2, next: http.DefaultTransport}}
+
+	start := time.Now()
+	resp, err := client.Post(server.URL, "application/x-www-form-urlencoded", strings.NewReader("name=Seat"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected the retry to succeed, got %d", resp.StatusCode)
+	}
+	if len(bodies) != 2 || bodies[1] != "name=Seat" {
+		t.Errorf("expected the body to be sent again, got %q", bodies)
+	}
+	if elapsed := time.Since(start); elapsed < minRateLimitDelay*3/4 {
+		t.Errorf("expected the retry to back off, it was sent after %s", elapsed)
+	}
+}
+
+func TestRateLimitTransportGivesUp(t *testing.T) {
+	cases := []struct {
+		name       string
+		maxRetries int64
+		header     string
+		requests   int
+	}{
+		{
+			name:       "retries exhausted",
+			maxRetries: 1,
+			requests:   2,
+		},
+		{
+			name:     "retries disabled",
+			requests: 1,
+		},
+		{
+			name:       "left to stripe-go",
+			maxRetries: 2,
+			header:     "false",
+			requests:   1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if tc.header != "" {
+					w.Header().Set("Stripe-Should-Retry", tc.header)
+				}
+				w.WriteHeader(http.StatusTooManyRequests)
+			}))
+			defer server.Close()
+
+			client := &http.Client{Transport: &rateLimitTransport{maxRetries: tc.maxRetries, next: http.DefaultTransport}}
+
+			resp, err := client.Get(server.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != http.StatusTooManyRequests {
+				t.Errorf("expected the last 429 to be returned, got %d", resp.StatusCode)
+			}
+			if requests != tc.requests {
+				t.Errorf("expected %d requests, got %d", tc.requests, requests)
+			}
+		})
+	}
+}
+
+func TestRateLimitTransportCanceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &rateLimitTransport{maxRetries: 2, next: http.DefaultTransport}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Do(req); err == nil {
+		t.Error("expected the canceled backoff to fail the request")
+	}
+}
+
+func TestRateLimitDelay(t *testing.T) {
+	retryAfter := &http.Response{Header: http.Header{"Retry-After": []string{"3"}}}
+	if got := rateLimitDelay(retryAfter, 0); got != 3*time.Second {
+		t.Errorf("expected Retry-After to be honored, got %s", got)
+	}
+
+	for attempt, want := range map[int64]time.Duration{
+		0:  minRateLimitDelay,
+		1:  2 * minRateLimitDelay,
+		2:  4 * minRateLimitDelay,
+		10: maxRateLimitDelay,
+		70: maxRateLimitDelay,
+	} {
+		got := rateLimitDelay(&http.Response{Header: http.Header{}}, attempt)
+		if got > want || got < want*3/4 {
+			t.Errorf("attempt %d: expected a delay between %s and %s, got %s", attempt, want*3/4, want, got)
+		}
+	}
+}
+
+func TestRateLimitTransportReserve(t *testing.T) {
+	transport := &rateLimitTransport{interval: rateLimitInterval(10)}
+
+	first, second, third := transport.reserve(), transport.reserve(), transport.reserve()
+	if first != 0 {
+		t.Errorf("expected the first request to go right away, waited %s", first)
+	}
+	if second <= 50*time.Millisecond || second > 100*time.Millisecond {
+		t.Errorf("expected the second request to wait about 100ms, waited %s", second)
+	}
+	if third <= 150*time.Millisecond || third > 200*time.Millisecond {
+		t.Errorf("expected the third request to wait about 200ms, waited %s", third)
+	}
+
+	if got := (&rateLimitTransport{}).reserve(); got != 0 {
+		t.Errorf("expected no pacing without a rate limit, waited %s", got)
+	}
+}
@@ -0,0 +1,85 @@
+package stripe
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var validateTaxBehavior = validateEnum("unspecified", "inclusive", "exclusive")
+
+var validateAccountID = validation.StringMatch(regexp.MustCompile(`^acct_`), "must be a connected account ID (acct_...)")
+
+// validateCountry checks two-letter ISO 3166-1 country codes, in any case.
+var validateCountry = validation.StringMatch(regexp.MustCompile(`^[A-Za-z]{2}$`), "must be a two-letter country code, e.g. US")
+
+// validateInterval checks the billing interval of plans and recurring prices.
+var validateInterval = validateEnum("day", "week", "month", "year")
+
+// validateUsageType checks the usage type of plans and recurring prices.
+var validateUsageType = validateEnum("licensed", "metered")
+
+// validateEnum works like validation.StringInSlice, but reports invalid
+// values with the same message as enumError.
+func validateEnum(values ...string) schema.SchemaValidateFunc {
+	return func(i interface{}, k string) (warnings []string, errors []error) {
+		v, ok := i.(string)
+		if !ok {
+			errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+			return warnings, errors
+		}
+
+		for _, value := range values {
+			if v == value {
+				return warnings, errors
+			}
+		}
+
+		errors = append(errors, enumError(k, v, values))
+		return warnings, errors
+	}
+}
+
+func enumError(key, value string, values []string) error {
+	return fmt.Errorf("\"%s\" is not a valid value for \"%s\", expected one of ( %s )", value, key, strings.Join(values, " | "))
+}
+
+// stripeCurrencies lists the ISO 4217 codes Stripe accepts for charges.
+var stripeCurrencies = map[string]bool{}
+
+func init() {
+	for _, c := range strings.Fields(`
+		aed afn all amd ang aoa ars aud awg azn bam bbd bdt bgn bhd bif bmd bnd
+		bob brl bsd bwp byn bzd cad cdf chf clp cny cop crc cve czk djf dkk dop
+		dzd egp etb eur fjd fkp gbp gel gip gmd gnf gtq gyd hkd hnl hrk htg huf
+		idr ils inr isk jmd jod jpy kes kgs khr kmf krw kwd kyd kzt lak lbp lkr
+		lrd lsl mad mdl mga mkd mmk mnt mop mro mur mvr mwk mxn myr mzn nad ngn
+		nio nok npr nzd omr pab pen pgk php pkr pln pyg qar ron rsd rub rwf sar
+		sbd scr sek sgd shp sle sll sos srd std szl thb tjs tnd top try ttd twd
+		tzs uah ugx usd uyu uzs vnd vuv wst xaf xcd xof xpf yer zar zmw`) {
+		stripeCurrencies[c] = true
+	}
+}
+
+// validateCurrency accepts currency codes in any case; pair it with
+// lowercaseString so they are stored the way Stripe returns them.
+func validateCurrency(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+		return warnings, errors
+	}
+
+	if !stripeCurrencies[strings.ToLower(v)] {
+		errors = append(errors, fmt.Errorf("\"%s\" is not a currency supported by Stripe for \"%s\"", v, k))
+	}
+
+	return warnings, errors
+}
+
+func lowercaseString(i interface{}) string {
+	return strings.ToLower(i.(string))
+}
@@ -0,0 +1,131 @@
+package stripe
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestValidateEnum(t *testing.T) {
+	validate := validateEnum("a", "b", "c")
+
+	cases := []struct {
+		value interface{}
+		err   string
+	}{
+		{value: "a"},
+		{value: "c"},
+		{value: "d", err: `"d" is not a valid value for "key", expected one of ( a | b | c )`},
+		{value: "A", err: `"A" is not a valid value for "key", expected one of ( a | b | c )`},
+		{value: "", err: `"" is not a valid value for "key", expected one of ( a | b | c )`},
+		{value: 1, err: "expected type of key to be string"},
+	}
+
+	for _, tc := range cases {
+		_, errs := validate(tc.value, "key")
+		if tc.err == "" {
+			if len(errs) != 0 {
+				t.Errorf("%v: unexpected errors %v", tc.value, errs)
+			}
+			continue
+		}
+		if len(errs) != 1 || errs[0].Error() != tc.err {
+			t.Errorf("%v: expected error %q, got %v", tc.value, tc.err, errs)
+		}
+	}
+}
+
+func TestEnumFields(t *testing.T) {
+	cases := []struct {
+		resource string
+		field    string
+		config   map[string]interface{}
+		err      string
+	}{
+		{
+			resource: "stripe_coupon",
+			field:    "duration",
+			config:   map[string]interface{}{"duration": "weekly", "percent_off": 10},
+			err:      `"weekly" is not a valid value for "duration", expected one of ( forever | once | repeating )`,
+		},
+		{
+			resource: "stripe_plan",
+			field:    "interval",
+			config:   map[string]interface{}{"amount": 100, "currency": "usd", "product": "prod_123", "interval": "monthly"},
+			err:      `"monthly" is not a valid value for "interval", expected one of ( day | week | month | year )`,
+		},
+		{
+			resource: "stripe_plan",
+			field:    "billing_scheme",
+			config:   map[string]interface{}{"amount": 100, "currency": "usd", "product": "prod_123", "interval": "month", "billing_scheme": "flat"},
+			err:      `"flat" is not a valid value for "billing_scheme", expected one of ( per_unit | tiered )`,
+		},
+		{
+			resource: "stripe_plan",
+			field:    "usage_type",
+			config:   map[string]interface{}{"amount": 100, "currency": "usd", "product": "prod_123", "interval": "month", "usage_type": "metred"},
+			err:      `"metred" is not a valid value for "usage_type", expected one of ( licensed | metered )`,
+		},
+		{
+			resource: "stripe_price",
+			field:    "recurring.interval",
+			config:   map[string]interface{}{"currency": "usd", "product": "prod_123", "recurring": map[string]interface{}{"interval": "monthly"}},
+			err:      `"monthly" is not a valid value for "recurring.interval", expected one of ( day | week | month | year )`,
+		},
+		{
+			resource: "stripe_price",
+			field:    "recurring.usage_type",
+			config:   map[string]interface{}{"currency": "usd", "product": "prod_123", "recurring": map[string]interface{}{"interval": "month", "usage_type": "metred"}},
+			err:      `"metred" is not a valid value for "recurring.usage_type", expected one of ( licensed | metered )`,
+		},
+		{
+			resource: "stripe_price",
+			field:    "billing_scheme",
+			config:   map[string]interface{}{"currency": "usd", "product": "prod_123", "billing_scheme": "flat"},
+			err:      `"flat" is not a valid value for "billing_scheme", expected one of ( per_unit | tiered )`,
+		},
+		{
+			resource: "stripe_price",
+			field:    "tax_behavior",
+			config:   map[string]interface{}{"currency": "usd", "product": "prod_123", "tax_behavior": "included"},
+			err:      `"included" is not a valid value for "tax_behavior", expected one of ( unspecified | inclusive | exclusive )`,
+		},
+		{
+			resource: "stripe_price",
+			field:    "currency_options.tax_behavior",
+			config:   map[string]interface{}{"currency": "usd", "product": "prod_123", "currency_options": []interface{}{map[string]interface{}{"currency": "eur", "tax_behavior": "included"}}},
+			err:      `"included" is not a valid value for "currency_options`,
+		},
+	}
+
+	provider := Provider()
+	for _, tc := range cases {
+		t.Run(tc.resource+"."+tc.field, func(t *testing.T) {
+			diags := provider.ResourcesMap[tc.resource].Validate(terraform.NewResourceConfigRaw(tc.config))
+
+			found := make([]string, 0, len(diags))
+			for _, d := range diags {
+				found = append(found, d.Summary+" "+d.Detail)
+				if strings.Contains(d.Summary, tc.err) || strings.Contains(d.Detail, tc.err) {
+					return
+				}
+			}
+			t.Errorf("expected error %q, got %v", tc.err, found)
+		})
+	}
+}
+
+func TestEnumFieldsValid(t *testing.T) {
+	r := Provider().ResourcesMap["stripe_price"]
+	diags := r.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"currency":       "usd",
+		"product":        "prod_123",
+		"billing_scheme": "per_unit",
+		"tax_behavior":   "exclusive",
+		"recurring":      map[string]interface{}{"interval": "month", "usage_type": "metered"},
+	}))
+	if diags.HasError() {
+		t.Errorf("unexpected errors: %v", diags)
+	}
+}